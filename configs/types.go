@@ -48,15 +48,19 @@ type GenericService struct {
 // AppMakerService is the default configuration for appmaker microservice
 type AppMakerService struct {
 	GenericService
-	MetricsInterval time.Duration `toml:"metrics_interval"`
+	MetricsInterval    time.Duration `toml:"metrics_interval"`
+	HealthCheckTimeout time.Duration `toml:"health_check_timeout"`
+	ReconcileInterval  time.Duration `toml:"reconcile_interval"`
+	CollectGarbage     bool          `toml:"collect_garbage"`
 }
 
 // MasterService is the default configuration for Master microservice
 type MasterService struct {
 	GenericService
-	CleanupInterval time.Duration   `toml:"cleanup_interval"`
-	MongoDB         DatabaseService `toml:"mongodb"`
-	Redis           DatabaseService `toml:"redis"`
+	CleanupInterval    time.Duration   `toml:"cleanup_interval"`
+	SchedulingStrategy string          `toml:"scheduling_strategy"`
+	MongoDB            DatabaseService `toml:"mongodb"`
+	Redis              DatabaseService `toml:"redis"`
 }
 
 // GenSSHService is the configuration for GenSSH microservice
@@ -68,19 +72,42 @@ type GenSSHService struct {
 	EntrypointIP    string   `toml:"entrypoint_ip"`
 }
 
+// ACMEConfig is the configuration for obtaining certificates over ACME in GenProxy microservice
+type ACMEConfig struct {
+	PlugIn        bool          `toml:"plugin"`
+	Directory     string        `toml:"directory"`
+	Email         string        `toml:"email"`
+	Challenge     string        `toml:"challenge"`
+	RenewBefore   time.Duration `toml:"renew_before"`
+	CACertificate string        `toml:"ca_certificate"`
+}
+
 // SSLConfig is the configuration for SSL in GenProxy microservice
 type SSLConfig struct {
-	PlugIn      bool   `toml:"plugin"`
-	Port        int    `toml:"port"`
-	Certificate string `toml:"certificate"`
-	PrivateKey  string `toml:"private_key"`
+	PlugIn      bool       `toml:"plugin"`
+	Port        int        `toml:"port"`
+	Certificate string     `toml:"certificate"`
+	PrivateKey  string     `toml:"private_key"`
+	HTTPSPolicy string     `toml:"https_policy"`
+	HSTS        types.HSTS `toml:"hsts"`
+	ACME        ACMEConfig `toml:"acme"`
+}
+
+// HealthCheckConfig is the configuration for the health checks of the upstreams in GenProxy microservice
+type HealthCheckConfig struct {
+	Interval     time.Duration `toml:"interval"`
+	MaxFailures  int           `toml:"max_failures"`
+	EjectionTime time.Duration `toml:"ejection_time"`
 }
 
 // GenProxyService is the configuration for GenProxy microservice
 type GenProxyService struct {
 	GenericService
-	SSL                  SSLConfig     `toml:"ssl"`
-	RecordUpdateInterval time.Duration `toml:"record_update_interval"`
+	SSL                  SSLConfig         `toml:"ssl"`
+	RecordUpdateInterval time.Duration     `toml:"record_update_interval"`
+	WakeTimeout          time.Duration     `toml:"wake_timeout"`
+	AccessLog            string            `toml:"access_log"`
+	HealthCheck          HealthCheckConfig `toml:"health_check"`
 }
 
 // GenDNSService is the configuration for GenDNS microservice
@@ -100,10 +127,12 @@ type DatabaseService struct {
 // DbMakerService is the configuration for DbMaker microservice
 type DbMakerService struct {
 	GenericService
-	MySQL      DatabaseService `toml:"mysql"`
-	MongoDB    DatabaseService `toml:"mongodb"`
-	PostgreSQL DatabaseService `toml:"postgresql"`
-	Redis      DatabaseService `toml:"redis"`
+	ReconcileInterval time.Duration   `toml:"reconcile_interval"`
+	CollectGarbage    bool            `toml:"collect_garbage"`
+	MySQL             DatabaseService `toml:"mysql"`
+	MongoDB           DatabaseService `toml:"mongodb"`
+	PostgreSQL        DatabaseService `toml:"postgresql"`
+	Redis             DatabaseService `toml:"redis"`
 }
 
 // JikanService is the configuration for Jikan microservice
@@ -141,18 +170,19 @@ type Services struct {
 
 // GasperCfg is the configuration for the entire project
 type GasperCfg struct {
-	Debug       bool       `toml:"debug"`
-	Domain      string     `toml:"domain"`
-	Secret      string     `toml:"secret"`
-	ProjectRoot string     `toml:"project_root"`
-	RcFile      string     `toml:"rc_file"`
-	OfflineMode bool       `toml:"offline_mode"`
-	DNSServers  []string   `toml:"dns_servers"`
-	JWT         JWT        `toml:"jwt"`
-	Admin       Admin      `toml:"admin"`
-	Cloudflare  Cloudflare `toml:"cloudflare"`
-	Mongo       Mongo      `toml:"mongo"`
-	Redis       Redis      `toml:"redis"`
-	Images      Images     `toml:"images"`
-	Services    Services   `toml:"services"`
+	Debug       bool              `toml:"debug"`
+	Domain      string            `toml:"domain"`
+	Secret      string            `toml:"secret"`
+	ProjectRoot string            `toml:"project_root"`
+	RcFile      string            `toml:"rc_file"`
+	OfflineMode bool              `toml:"offline_mode"`
+	DNSServers  []string          `toml:"dns_servers"`
+	Labels      map[string]string `toml:"labels"`
+	JWT         JWT               `toml:"jwt"`
+	Admin       Admin             `toml:"admin"`
+	Cloudflare  Cloudflare        `toml:"cloudflare"`
+	Mongo       Mongo             `toml:"mongo"`
+	Redis       Redis             `toml:"redis"`
+	Images      Images            `toml:"images"`
+	Services    Services          `toml:"services"`
 }
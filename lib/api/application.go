@@ -3,35 +3,85 @@ package api
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/sdslabs/gasper/configs"
 	"github.com/sdslabs/gasper/lib/docker"
 	"github.com/sdslabs/gasper/lib/git"
+	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/types"
 	gogit "gopkg.in/src-d/go-git.v4"
 )
 
-func cloneRepo(app types.Application, storedir string, clone chan types.ResponseError) {
+// deployKey returns the decrypted private key of an application's deploy key
+func deployKey(name string) ([]byte, error) {
+	key, err := mongo.FetchDeployKey(name)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Decrypt(key.PrivateKey)
+}
+
+func cloneRepo(app types.Application, deployment *Deployment, clone chan types.ResponseError) {
+	storedir := deployment.StoreDir
 	err := os.MkdirAll(storedir, 0755)
 	if err != nil {
 		clone <- types.NewResErr(500, "storage directory not created", err)
 		return
 	}
 
-	if app.HasGitAccessToken() {
+	// A commit pinned by the deployment takes precedence over the one pinned by the application
+	commit := deployment.Commit
+	if commit == "" {
+		commit = app.GetGitCommit()
+	}
+
+	// Repositories accessed over SSH are cloned using the application's deploy key
+	var privateKey []byte
+	if git.IsSSHURL(app.GetGitRepositoryURL()) {
+		privateKey, err = deployKey(app.GetName())
+		if err != nil {
+			clone <- types.NewResErr(500, "deploy key not found", err)
+			return
+		}
+	}
+
+	switch {
+	case commit != "":
+		auth := git.TokenAuth(app.GetGitAccessToken())
+		if privateKey != nil {
+			auth, err = git.KeyAuth(app.GetGitRepositoryURL(), privateKey)
+			if err != nil {
+				clone <- types.NewResErr(500, "deploy key not loaded", err)
+				return
+			}
+		}
+		err = git.CloneAtCommit(
+			app.GetGitRepositoryURL(),
+			app.GetGitReference(),
+			commit,
+			storedir,
+			auth,
+		)
+	case privateKey != nil:
+		err = git.CloneWithKey(
+			app.GetGitRepositoryURL(),
+			app.GetGitReference(),
+			storedir,
+			privateKey,
+		)
+	case app.HasGitAccessToken():
 		err = git.CloneWithToken(
 			app.GetGitRepositoryURL(),
-			app.GetGitRepositoryBranch(),
+			app.GetGitReference(),
 			storedir,
 			app.GetGitAccessToken(),
 		)
-	} else {
+	default:
 		err = git.Clone(
 			app.GetGitRepositoryURL(),
-			app.GetGitRepositoryBranch(),
+			app.GetGitReference(),
 			storedir,
 		)
 	}
@@ -44,16 +94,29 @@ func cloneRepo(app types.Application, storedir string, clone chan types.Response
 		}
 		return
 	}
+
+	commit, err = git.HeadCommit(storedir)
+	if err != nil {
+		clone <- types.NewResErr(500, "repository commit not resolved", err)
+		return
+	}
+	app.SetCommit(commit)
 	clone <- nil
 }
 
-func setupContainer(app types.Application, storedir string, setup chan types.ResponseError) {
+func setupContainer(app types.Application, containerName, storedir string, setup chan types.ResponseError) {
 	confFileName := fmt.Sprintf("%s.gasper.conf", app.GetName())
 	workdir := fmt.Sprintf("%s/%s", configs.GasperConfig.ProjectRoot, app.GetName())
 
+	// Applications built from a Dockerfile have no storage directory to be mounted
+	// and use the working directory defined in their image
+	if storedir == "" {
+		workdir = ""
+	}
+
 	// create the container
 	containerID, err := docker.CreateApplicationContainer(types.ApplicationContainer{
-		Name:            app.GetName(),
+		Name:            containerName,
 		Image:           app.GetDockerImage(),
 		ApplicationPort: app.GetApplicationPort(),
 		ContainerPort:   app.GetContainerPort(),
@@ -102,31 +165,39 @@ func setupContainer(app types.Application, storedir string, setup chan types.Res
 }
 
 // createBasicApplication spawns a new container with the application of a particular service
-func createBasicApplication(app types.Application) []types.ResponseError {
-	storepath, _ := os.Getwd()
-	storedir := filepath.Join(storepath, fmt.Sprintf("storage/%s", app.GetName()))
+func createBasicApplication(app types.Application, deployment *Deployment) []types.ResponseError {
 	setup := make(chan types.ResponseError)
 	clone := make(chan types.ResponseError)
 
 	// Step 1: clone the repo in the storage
-	go cloneRepo(app, storedir, clone)
+	go cloneRepo(app, deployment, clone)
 
 	// Step 2: setup the container
-	go setupContainer(app, storedir, setup)
+	go setupContainer(app, deployment.ContainerName, deployment.StoreDir, setup)
 
 	return []types.ResponseError{<-setup, <-clone}
 }
 
+// rcFileCommand returns the command executing the run commands file present in an application's repository
+func rcFileCommand() []string {
+	return []string{"sh", "-c",
+		fmt.Sprintf(`chmod 755 ./%s &> /proc/1/fd/1 && ./%s &> /proc/1/fd/1`,
+			configs.GasperConfig.RcFile, configs.GasperConfig.RcFile)}
+}
+
 // SetupApplication sets up a basic container for the application with all the prerequisites
-func SetupApplication(app types.Application) types.ResponseError {
+// Applications without a run commands file are left in the building state and must then be
+// passed to BuildAndRun once they have been stored
+func SetupApplication(app types.Application, deployment *Deployment) types.ResponseError {
 	containerPort, err := utils.GetFreePort()
 	if err != nil {
 		return types.NewResErr(500, "No free port available", err)
 	}
 
 	app.SetContainerPort(containerPort)
+	app.SetState(types.AppCloning)
 
-	errList := createBasicApplication(app)
+	errList := createBasicApplication(app, deployment)
 
 	for _, err := range errList {
 		if err != nil {
@@ -135,11 +206,7 @@ func SetupApplication(app types.Application) types.ResponseError {
 	}
 
 	if app.HasRcFile() {
-		cmd := []string{"sh", "-c",
-			fmt.Sprintf(`chmod 755 ./%s &> /proc/1/fd/1 && ./%s &> /proc/1/fd/1`,
-				configs.GasperConfig.RcFile, configs.GasperConfig.RcFile)}
-
-		_, err = docker.ExecDetachedProcess(app.GetContainerID(), cmd)
+		_, err = docker.ExecDetachedProcess(app.GetContainerID(), rcFileCommand())
 		if err != nil {
 			// this error cannot be ignored; the chances of error here are very less
 			// but if an error arises, this means there's some issue with "execing"
@@ -147,9 +214,74 @@ func SetupApplication(app types.Application) types.ResponseError {
 			// hence we also run the cleanup here so that nothing else goes wrong
 			return types.NewResErr(500, "cannot exec rc file", err)
 		}
+		app.SetState(types.AppRunning)
 	} else {
-		go buildAndRun(app)
+		app.SetState(types.AppBuilding)
+	}
+
+	return nil
+}
+
+// buildImage builds the application's docker image from the Dockerfile present in its
+// cloned repository and tags the image with the application's name and current commit
+func buildImage(app types.Application, storedir string) types.ResponseError {
+	buildContext, err := utils.NewTarArchiveFromPath(storedir)
+	if err != nil {
+		return types.NewResErr(500, "build context not created", err)
 	}
 
+	tag := fmt.Sprintf("%s/%s:%s", imageRepository, app.GetName(), app.GetCommit()[:12])
+	build := newBuild(app)
+	output, err := docker.BuildImage(buildContext, app.GetDockerfile(), tag)
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+	build.AddStep(fmt.Sprintf("docker build -f %s -t %s .", app.GetDockerfile(), tag), exitCode, tailOutput(output))
+	finishBuild(build, err)
+	if err != nil {
+		return types.NewResErr(500, "image not built", err)
+	}
+
+	app.SetDockerImage(tag)
+	return nil
+}
+
+// SetupDockerfileApplication clones the application's repository, builds a docker image from
+// the Dockerfile present in it and sets up a container for the application using that image
+func SetupDockerfileApplication(app types.Application, deployment *Deployment) types.ResponseError {
+	containerPort, err := utils.GetFreePort()
+	if err != nil {
+		return types.NewResErr(500, "No free port available", err)
+	}
+
+	app.SetContainerPort(containerPort)
+	app.SetState(types.AppCloning)
+
+	clone := make(chan types.ResponseError)
+	setup := make(chan types.ResponseError)
+
+	// Step 1: clone the repo in the storage
+	go cloneRepo(app, deployment, clone)
+	if resErr := <-clone; resErr != nil {
+		return resErr
+	}
+
+	// Step 2: build the image from the repo's Dockerfile unless the image of the release
+	// being redeployed is still present on the node
+	app.SetState(types.AppBuilding)
+	if deployment.Image != "" && docker.ImageExists(deployment.Image) {
+		app.SetDockerImage(deployment.Image)
+	} else if resErr := buildImage(app, deployment.StoreDir); resErr != nil {
+		return resErr
+	}
+
+	// Step 3: setup the container from the built image
+	app.SetState(types.AppStarting)
+	go setupContainer(app, deployment.ContainerName, "", setup)
+	if resErr := <-setup; resErr != nil {
+		return resErr
+	}
+	app.SetState(types.AppRunning)
 	return nil
 }
@@ -2,24 +2,144 @@ package api
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sdslabs/gasper/lib/docker"
+	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/types"
 )
 
-// buildAndRun installs application dependencies and starts the application
-func buildAndRun(app types.Application) {
+// newBuild creates a new build record for the application and stores it in mongoDB
+func newBuild(app types.Application) *types.Build {
+	build := &types.Build{
+		ID:        uuid.New().String(),
+		Name:      app.GetName(),
+		Status:    types.BuildInProgress,
+		StartedAt: time.Now().Unix(),
+	}
+	if _, err := mongo.RegisterBuild(build); err != nil {
+		utils.LogError("API-Build-And-Run-1", err)
+	}
+	return build
+}
+
+// finishBuild marks the build as finished along with the error (if any) which caused it to fail
+func finishBuild(build *types.Build, err error) {
+	build.FinishedAt = time.Now().Unix()
+	if err != nil {
+		build.Status = types.BuildFailed
+		build.Error = err.Error()
+	} else {
+		build.Status = types.BuildSucceeded
+	}
+	if err := mongo.UpdateBuild(types.M{mongo.BuildIDKey: build.ID}, build); err != nil {
+		utils.LogError("API-Build-And-Run-2", err)
+	}
+}
+
+// updateState updates the lifecycle state of the application both in its context and in mongoDB
+func updateState(app types.Application, state string) {
+	app.SetState(state)
+	if err := mongo.UpdateAppState(app.GetName(), state); err != nil {
+		utils.LogError("API-Build-And-Run-3", err)
+	}
+}
+
+// updateReplicaState updates the lifecycle state of the application's replica deployed
+// on the current node both in its context and in mongoDB
+func updateReplicaState(app types.Application, state string) {
+	app.SetState(state)
+	if err := mongo.UpdateReplicaState(app.GetName(), utils.HostIP, state); err != nil {
+		utils.LogError("API-Build-And-Run-6", err)
+	}
+}
+
+// updateRebuildState updates the lifecycle state of the application's rebuilt deployment both in its
+// context and in mongoDB, the state of the deployment serving the application is left untouched
+func updateRebuildState(app types.Application, state string) {
+	app.SetState(state)
+	if err := mongo.UpdateAppRebuildState(app.GetName(), state); err != nil {
+		utils.LogError("API-Build-And-Run-7", err)
+	}
+}
+
+// tailOutput keeps only the last few lines of a command's output so that
+// the build record stays within mongoDB's document size limits
+func tailOutput(output []string) []string {
+	if len(output) > maxBuildOutputLines {
+		return output[len(output)-maxBuildOutputLines:]
+	}
+	return output
+}
+
+// BuildAndRun installs application dependencies and starts the application
+// The exit code and output of every build command is recorded separately from the container logs
+// Applications which are not waiting to be built (rc file or Dockerfile based) are skipped
+func BuildAndRun(app types.Application) {
+	buildAndRun(app, updateState)
+}
+
+// BuildAndRunReplica installs dependencies and starts a replica of the application deployed
+// on the current node, the lifecycle state is recorded for the replica instead of the application
+func BuildAndRunReplica(app types.Application) {
+	buildAndRun(app, updateReplicaState)
+}
+
+// BuildAndRunRebuild installs dependencies and starts the rebuilt deployment of the application
+// alongside the one serving it, the lifecycle state is recorded as the state of the rebuild
+func BuildAndRunRebuild(app types.Application) {
+	buildAndRun(app, updateRebuildState)
+}
+
+// Run executes the run commands file or the run commands of an application whose container has been
+// started again, the dependencies installed while building the application are kept by its container
+func Run(app types.Application) error {
+	if app.HasRcFile() {
+		_, err := docker.ExecDetachedProcess(app.GetContainerID(), rcFileCommand())
+		return err
+	}
+	for _, cmd := range app.GetRunCommands() {
+		_, err := docker.ExecDetachedProcess(app.GetContainerID(), []string{"sh", "-c", fmt.Sprintf("%s &> /proc/1/fd/1", cmd)})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildAndRun executes the build and run commands of the application while
+// recording its lifecycle state with the given function
+func buildAndRun(app types.Application, setState func(types.Application, string)) {
+	if app.GetState() != types.AppBuilding {
+		return
+	}
+
+	build := newBuild(app)
 	for _, cmd := range app.GetBuildCommands() {
-		_, err := docker.ExecProcess(app.GetContainerID(), []string{"sh", "-c", fmt.Sprintf("%s &> /proc/1/fd/1", cmd)})
+		output, exitCode, err := docker.ExecProcessWithOutput(app.GetContainerID(), []string{"sh", "-c", cmd})
+		build.AddStep(cmd, exitCode, tailOutput(output))
+		if err == nil && exitCode != 0 {
+			err = fmt.Errorf("command `%s` exited with status %d", cmd, exitCode)
+		}
 		if err != nil {
-			utils.LogError("API-Build-And-Run-1", err)
+			utils.LogError("API-Build-And-Run-4", err)
+			finishBuild(build, err)
+			setState(app, types.AppFailed)
+			return
 		}
 	}
+	finishBuild(build, nil)
+
+	setState(app, types.AppStarting)
 	for _, cmd := range app.GetRunCommands() {
 		_, err := docker.ExecDetachedProcess(app.GetContainerID(), []string{"sh", "-c", fmt.Sprintf("%s &> /proc/1/fd/1", cmd)})
 		if err != nil {
-			utils.LogError("API-Build-And-Run-2", err)
+			utils.LogError("API-Build-And-Run-5", err)
+			setState(app, types.AppFailed)
+			return
 		}
 	}
+	setState(app, types.AppRunning)
 }
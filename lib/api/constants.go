@@ -0,0 +1,9 @@
+package api
+
+const (
+	// imageRepository is the repository under which docker images built by gasper are tagged
+	imageRepository = "gasper"
+
+	// maxBuildOutputLines is the maximum number of lines of output stored for a single build command
+	maxBuildOutputLines = 500
+)
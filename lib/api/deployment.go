@@ -0,0 +1,74 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/sdslabs/gasper/lib/docker"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// candidateSuffix is appended to the container name and storage directory of an application
+// being rebuilt alongside its live deployment, application names are alphanumeric hence
+// the suffixed names never collide with another application
+const candidateSuffix = "-next"
+
+// Deployment denotes the container and the storage directory holding a deployment of an application
+// Commit and Image pin the deployment to a release of the application when set
+type Deployment struct {
+	ContainerName string
+	StoreDir      string
+	Commit        string
+	Image         string
+}
+
+// StorageRoot returns the directory holding the storage directories of all applications
+func StorageRoot() string {
+	storepath, _ := os.Getwd()
+	return filepath.Join(storepath, "storage")
+}
+
+// storageDir returns the storage directory of an application
+func storageDir(name string) string {
+	return filepath.Join(StorageRoot(), name)
+}
+
+// NewDeployment returns the deployment in which an application is created
+func NewDeployment(name string) *Deployment {
+	return &Deployment{
+		ContainerName: name,
+		StoreDir:      storageDir(name),
+	}
+}
+
+// Deployments returns the live deployment of an application along with the candidate deployment
+// in which the application can be rebuilt without disturbing the live one
+// The storage directories of both alternate between `storage/<name>` and `storage/<name>-next`
+// across rebuilds because the live container keeps its directory mounted until it is removed
+func Deployments(app types.Application) (live, candidate *Deployment) {
+	live = NewDeployment(app.GetName())
+	candidate = &Deployment{
+		ContainerName: app.GetName() + candidateSuffix,
+		StoreDir:      storageDir(app.GetName() + candidateSuffix),
+	}
+	mounts, err := docker.InspectContainerMounts(app.GetContainerID())
+	if err != nil {
+		utils.LogError("API-Deployment-1", err)
+		return live, candidate
+	}
+	if utils.Contains(mounts, candidate.StoreDir) {
+		live.StoreDir, candidate.StoreDir = candidate.StoreDir, live.StoreDir
+	}
+	return live, candidate
+}
+
+// StorageDirs returns all the storage directories which can be occupied by an application
+func StorageDirs(name string) []string {
+	return []string{storageDir(name), storageDir(name + candidateSuffix)}
+}
+
+// ContainerNames returns all the container names which can be taken by an application
+func ContainerNames(name string) []string {
+	return []string{name, name + candidateSuffix}
+}
@@ -218,3 +218,150 @@ func DeleteRecord(name, instanceType string) (*GenericResponse, error) {
 	}
 	return data, nil
 }
+
+// CreateCustomHostname registers a custom domain of an application with the zone
+// so that its requests are proxied to the zone
+func CreateCustomHostname(hostname string) (*CustomHostnameResponse, error) {
+	zoneID, err := getZoneID()
+	if err != nil {
+		return nil, err
+	}
+
+	payloadBytes, err := json.Marshal(&customHostnamePayload{Hostname: hostname})
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf(customHostnamesEndpoint, zoneID), bytes.NewBuffer(payloadBytes))
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	data := &CustomHostnameResponse{}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Success {
+		return nil, formatErrorResponse(data.Errors)
+	}
+	return data, nil
+}
+
+// DeleteCustomHostname removes a custom domain of an application from the zone
+func DeleteCustomHostname(hostname string) (*GenericResponse, error) {
+	zoneID, err := getZoneID()
+	if err != nil {
+		return nil, err
+	}
+
+	hostnameID, err := getCustomHostnameID(zoneID, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf(deleteCustomHostnameEndpoint, zoneID, hostnameID), nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	data := &GenericResponse{}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Success {
+		return nil, formatErrorResponse(data.Errors)
+	}
+	return data, nil
+}
+
+// CreateChallengeRecord creates a TXT record holding the value of an ACME DNS-01 challenge
+// The name of the record is fully qualified and must lie within the zone
+func CreateChallengeRecord(name, value string) (*SingleResponse, error) {
+	zoneID, err := getZoneID()
+	if err != nil {
+		return nil, err
+	}
+
+	payloadBytes, err := json.Marshal(&singlePayload{
+		Name:    name,
+		Type:    "TXT",
+		Content: value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf(createRecordEndpoint, zoneID), bytes.NewBuffer(payloadBytes))
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	data := &SingleResponse{}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Success {
+		return nil, formatErrorResponse(data.Errors)
+	}
+	return data, nil
+}
+
+// DeleteChallengeRecord deletes a TXT record created for an ACME DNS-01 challenge
+func DeleteChallengeRecord(recordID string) (*GenericResponse, error) {
+	zoneID, err := getZoneID()
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf(deleteRecordEndpoint, zoneID, recordID), nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	data := &GenericResponse{}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Success {
+		return nil, formatErrorResponse(data.Errors)
+	}
+	return data, nil
+}
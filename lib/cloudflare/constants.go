@@ -9,12 +9,18 @@ const (
 	// DatabaseInstance is the identifier attached with a database's DNS entry in cloudflare
 	DatabaseInstance = "db"
 
+	// ACMEChallenge is the identifier attached with the TXT records of ACME DNS-01 challenges in cloudflare
+	ACMEChallenge = "acme"
+
 	baseEndpoint         = "https://api.cloudflare.com/client/v4"
 	listZonesEndpoint    = baseEndpoint + "/zones"
 	fetchRecordEndpoint  = listZonesEndpoint + "/%s/dns_records"
 	createRecordEndpoint = listZonesEndpoint + "/%s/dns_records"
 	updateRecordEndpoint = listZonesEndpoint + "/%s/dns_records/%s"
 	deleteRecordEndpoint = listZonesEndpoint + "/%s/dns_records/%s"
+
+	customHostnamesEndpoint      = listZonesEndpoint + "/%s/custom_hostnames"
+	deleteCustomHostnameEndpoint = listZonesEndpoint + "/%s/custom_hostnames/%s"
 )
 
 var (
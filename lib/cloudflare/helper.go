@@ -1,8 +1,11 @@
 package cloudflare
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 
 	"github.com/sdslabs/gasper/types"
 )
@@ -40,3 +43,36 @@ func getRecordID(name, instanceType string) (string, error) {
 	}
 	return res.Result[0].ID, err
 }
+
+// getCustomHostnameID returns the ID of the desired custom hostname
+func getCustomHostnameID(zoneID, hostname string) (string, error) {
+	req, _ := http.NewRequest("GET", fmt.Sprintf(customHostnamesEndpoint, zoneID), nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	query := req.URL.Query()
+	query.Add("hostname", hostname)
+	req.URL.RawQuery = query.Encode()
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	data := &multiCustomHostnameResponse{}
+
+	err = json.Unmarshal(body, data)
+	if err != nil {
+		return "", err
+	}
+
+	if !data.Success {
+		return "", formatErrorResponse(data.Errors)
+	}
+	if len(data.Result) == 0 {
+		return "", fmt.Errorf("Custom hostname %s doesn't exist", hostname)
+	}
+	return data.Result[0].ID, nil
+}
@@ -22,6 +22,12 @@ type dnsRecord struct {
 	ZoneName string `json:"zone_name"`
 }
 
+type customHostnameRecord struct {
+	ID       string `json:"id"`
+	Hostname string `json:"hostname"`
+	Status   string `json:"status"`
+}
+
 // GenericResponse is the common response from Cloudflare API
 type GenericResponse struct {
 	Success bool            `json:"success"`
@@ -55,3 +61,21 @@ type singlePayload struct {
 	// IP address of the deployed application
 	Content string `json:"content,omitempty"`
 }
+
+// CustomHostnameResponse stores details of a single custom hostname
+type CustomHostnameResponse struct {
+	Result customHostnameRecord `json:"result"`
+	GenericResponse
+}
+
+// multiCustomHostnameResponse stores details of multiple custom hostnames
+type multiCustomHostnameResponse struct {
+	Result []customHostnameRecord `json:"result"`
+	GenericResponse
+}
+
+// customHostnamePayload is the request body for creating a new custom hostname
+type customHostnamePayload struct {
+	// Custom domain attached to an application
+	Hostname string `json:"hostname"`
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/sdslabs/gasper/types"
 )
 
+// RedisStorageRoot returns the directory holding the storage directories of all Redis databases
+func RedisStorageRoot() string {
+	return filepath.Join(storepath, "redis-storage")
+}
+
 // CreateRedisDB  creates a RedisDB container
 func CreateRedisDB(db types.Database) error {
 	port, err := utils.GetFreePort()
@@ -19,7 +24,7 @@ func CreateRedisDB(db types.Database) error {
 		return fmt.Errorf("Error while getting free port for container : %s", err)
 	}
 
-	storedir := filepath.Join(storepath, "redis-storage", db.GetName())
+	storedir := filepath.Join(RedisStorageRoot(), db.GetName())
 
 	if err := os.MkdirAll(storedir, 0755); err != nil {
 		return fmt.Errorf("Error while creating the directory : %s", err)
@@ -30,7 +35,7 @@ func CreateRedisDB(db types.Database) error {
 		ContainerPort: port,
 		DatabasePort:  6379,
 		WorkDir:       "/data/",
-		StoreDir:      filepath.Join(storepath, "redis-storage", db.GetName()),
+		StoreDir:      storedir,
 		Name:          db.GetName(),
 		Cmd:           []string{"redis-server", "--requirepass", db.GetPassword()},
 	})
@@ -53,7 +58,7 @@ func DeleteRedisDB(databaseName string) error {
 		return types.NewResErr(500, "container not deleted", err)
 	}
 
-	storedir := filepath.Join(storepath, "redis-storage", databaseName)
+	storedir := filepath.Join(RedisStorageRoot(), databaseName)
 
 	if err := os.RemoveAll(storedir); err != nil {
 		return fmt.Errorf("Error while deleting the database directory : %s", err)
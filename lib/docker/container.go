@@ -8,16 +8,27 @@ import (
 
 	dockerTypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/go-connections/nat"
 	"github.com/sdslabs/gasper/configs"
 	"github.com/sdslabs/gasper/types"
 	"golang.org/x/net/context"
 )
 
+const (
+	// InstanceLabel is the label of the containers created by Gasper denoting the kind of instance they hold
+	InstanceLabel = "io.gasper.instance"
+
+	// ApplicationLabel is the value of InstanceLabel for the containers of applications and their replicas
+	ApplicationLabel = "application"
+
+	// DatabaseLabel is the value of InstanceLabel for the containers of database servers and databases
+	DatabaseLabel = "database"
+)
+
 // CreateApplicationContainer creates a new container of the given container options, returns id of the container created
 func CreateApplicationContainer(containerCfg types.ApplicationContainer) (string, error) {
 	ctx := context.Background()
-	volume := fmt.Sprintf("%s:%s", containerCfg.StoreDir, containerCfg.WorkDir)
 
 	// convert map to list of strings
 	envArr := []string{}
@@ -34,8 +45,8 @@ func CreateApplicationContainer(containerCfg types.ApplicationContainer) (string
 			containerPortRule: struct{}{},
 		},
 		Env: envArr,
-		Volumes: map[string]struct{}{
-			volume: {},
+		Labels: map[string]string{
+			InstanceLabel: ApplicationLabel,
 		},
 		Healthcheck: &container.HealthConfig{
 			Test:     []string{"CMD-SHELL", fmt.Sprintf("curl --fail --silent http://localhost:%d/ || exit 1", containerCfg.ApplicationPort)},
@@ -46,9 +57,6 @@ func CreateApplicationContainer(containerCfg types.ApplicationContainer) (string
 	}
 
 	hostConfig := &container.HostConfig{
-		Binds: []string{
-			volume,
-		},
 		DNS: containerCfg.NameServers,
 		PortBindings: nat.PortMap{
 			nat.Port(containerPortRule): []nat.PortBinding{{
@@ -61,6 +69,16 @@ func CreateApplicationContainer(containerCfg types.ApplicationContainer) (string
 		},
 	}
 
+	// Applications built from a Dockerfile carry their source code inside the image
+	// hence the repository is mounted only when a storage directory is provided
+	if containerCfg.HasStoreDir() {
+		volume := fmt.Sprintf("%s:%s", containerCfg.StoreDir, containerCfg.WorkDir)
+		containerConfig.Volumes = map[string]struct{}{
+			volume: {},
+		}
+		hostConfig.Binds = []string{volume}
+	}
+
 	createdConf, err := cli.ContainerCreate(ctx, containerConfig, hostConfig, nil, containerCfg.Name)
 	if err != nil {
 		return "", err
@@ -86,6 +104,9 @@ func CreateDatabaseContainer(containerCfg types.DatabaseContainer) (string, erro
 			containerPortRule: struct{}{},
 		},
 		Env: envArr,
+		Labels: map[string]string{
+			InstanceLabel: DatabaseLabel,
+		},
 		Volumes: map[string]struct{}{
 			volume: {},
 		},
@@ -125,6 +146,18 @@ func StopContainer(containerID string) error {
 	return cli.ContainerStop(ctx, containerID, nil)
 }
 
+// RestartContainer restarts the container corresponding to given containerID
+func RestartContainer(containerID string) error {
+	ctx := context.Background()
+	return cli.ContainerRestart(ctx, containerID, nil)
+}
+
+// RenameContainer renames the container corresponding to given containerID
+func RenameContainer(containerID, name string) error {
+	ctx := context.Background()
+	return cli.ContainerRename(ctx, containerID, name)
+}
+
 // ListContainers lists all containers
 func ListContainers() ([]string, error) {
 	ctx := context.Background()
@@ -143,6 +176,48 @@ func ListContainers() ([]string, error) {
 	return list, nil
 }
 
+// ListContainerStates lists the states of all containers, such as running or exited, mapped to their names
+func ListContainerStates() (map[string]string, error) {
+	ctx := context.Background()
+	containers, err := cli.ContainerList(ctx, dockerTypes.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string)
+
+	for _, container := range containers {
+		if len(container.Names) > 0 && len(container.Names[0]) > 1 {
+			states[container.Names[0][1:]] = container.State
+		}
+	}
+	return states, nil
+}
+
+// ListLabelledContainers lists the creation times of the containers created by Gasper for the given
+// kind of instance mapped to their names
+func ListLabelledContainers(kind string) (map[string]time.Time, error) {
+	ctx := context.Background()
+	args := filters.NewArgs()
+	args.Add("label", fmt.Sprintf("%s=%s", InstanceLabel, kind))
+	containers, err := cli.ContainerList(ctx, dockerTypes.ContainerListOptions{
+		All:     true,
+		Filters: args,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := make(map[string]time.Time)
+
+	for _, container := range containers {
+		if len(container.Names) > 0 && len(container.Names[0]) > 1 {
+			created[container.Names[0][1:]] = time.Unix(container.Created, 0)
+		}
+	}
+	return created, nil
+}
+
 // ContainerStats returns container statistics using the containerID
 func ContainerStats(containerID string) (*types.Stats, error) {
 	ctx := context.Background()
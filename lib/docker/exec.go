@@ -1,7 +1,9 @@
 package docker
 
 import (
+	"bufio"
 	"errors"
+	"strings"
 
 	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
@@ -56,3 +58,46 @@ func ExecProcess(containerID string, command []string) (string, error) {
 	}
 	return execID, nil
 }
+
+// ExecProcessWithOutput executes a command in a blocking manner and returns the combined
+// output of the process (stdout and stderr) along with its exit code
+func ExecProcessWithOutput(containerID string, command []string) ([]string, int, error) {
+	ctx := context.Background()
+	config := types.ExecConfig{
+		Detach:       false,
+		Tty:          true,
+		Cmd:          command,
+		AttachStderr: true,
+		AttachStdout: true,
+	}
+	execProcess, err := cli.ContainerExecCreate(ctx, containerID, config)
+	if err != nil {
+		return nil, -1, err
+	}
+	execID := execProcess.ID
+	if execID == "" {
+		return nil, -1, errors.New("empty exec ID")
+	}
+	res, err := cli.ContainerExecAttach(ctx, execID, config)
+	if err != nil {
+		return nil, -1, err
+	}
+	defer res.Close()
+
+	// The process is attached with a TTY hence the output stream is not multiplexed
+	output := []string{}
+	scanner := bufio.NewScanner(res.Reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		output = append(output, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return output, -1, err
+	}
+
+	inspection, err := cli.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return output, -1, err
+	}
+	return output, inspection.ExitCode, nil
+}
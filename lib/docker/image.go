@@ -1,9 +1,12 @@
 package docker
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
@@ -47,3 +50,58 @@ func DirectPull(image string) error {
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+// ImageExists checks whether an image is present on the host
+func ImageExists(image string) bool {
+	ctx := context.Background()
+	_, _, err := cli.ImageInspectWithRaw(ctx, image)
+	return err == nil
+}
+
+// buildMessage is a single message from the JSON stream returned by the docker daemon
+// while building an image
+type buildMessage struct {
+	Stream      string `json:"stream"`
+	Error       string `json:"error"`
+	ErrorDetail struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// BuildImage builds a docker image from the build context using the given Dockerfile
+// and tags it with the given tag, returns the output of the build
+// Build context must be a tar archive
+func BuildImage(buildContext io.Reader, dockerfile, tag string) ([]string, error) {
+	ctx := context.Background()
+	res, err := cli.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        []string{tag},
+		Dockerfile:  dockerfile,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	output := []string{}
+	decoder := json.NewDecoder(res.Body)
+	for {
+		msg := &buildMessage{}
+		if err := decoder.Decode(msg); err != nil {
+			if err == io.EOF {
+				return output, nil
+			}
+			return output, err
+		}
+		if msg.Error != "" {
+			if msg.ErrorDetail.Message != "" {
+				return output, errors.New(msg.ErrorDetail.Message)
+			}
+			return output, errors.New(msg.Error)
+		}
+		if line := strings.TrimRight(msg.Stream, "\n"); line != "" {
+			output = append(output, line)
+		}
+	}
+}
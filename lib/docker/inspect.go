@@ -1,6 +1,9 @@
 package docker
 
 import (
+	"math"
+	"time"
+
 	dockerTypes "github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
@@ -14,3 +17,35 @@ func InspectContainerState(containerID string) (*dockerTypes.ContainerState, err
 	}
 	return containerStatus.ContainerJSONBase.State, nil
 }
+
+// InspectContainerMounts returns the source paths of the bind mounts of the container using the containerID
+func InspectContainerMounts(containerID string) ([]string, error) {
+	ctx := context.Background()
+	containerInfo, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	sources := []string{}
+	for _, mount := range containerInfo.Mounts {
+		sources = append(sources, mount.Source)
+	}
+	return sources, nil
+}
+
+// InspectHostResources returns the number of CPUs and the memory (in GB) of the host running the docker daemon
+func InspectHostResources() (float64, float64, error) {
+	ctx := context.Background()
+	info, err := cli.Info(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return float64(info.NCPU), float64(info.MemTotal) / math.Pow(1024, 3), nil
+}
+
+// Ping checks whether the docker daemon is responding
+func Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := cli.Ping(ctx)
+	return err
+}
@@ -36,7 +36,7 @@ func CreateApplication(language, owner, instanceURL string, data []byte) ([]byte
 }
 
 // RebuildApplication is a remote procedure call for rebuilding an application in a worker node
-func RebuildApplication(name, instanceURL string) ([]byte, error) {
+func RebuildApplication(name, triggeredBy, instanceURL string) ([]byte, error) {
 	conn, err := grpc.Dial(
 		instanceURL,
 		grpc.WithInsecure(),
@@ -51,7 +51,38 @@ func RebuildApplication(name, instanceURL string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	res, err := client.Rebuild(ctx, &pb.NameHolder{Name: name})
+	res, err := client.Rebuild(ctx, &pb.RebuildRequest{
+		Name:        name,
+		TriggeredBy: triggeredBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetData(), nil
+}
+
+// RollbackApplication is a remote procedure call for redeploying a release of an application in a worker node
+func RollbackApplication(name, release, triggeredBy, instanceURL string) ([]byte, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Rollback(ctx, &pb.RollbackRequest{
+		Name:        name,
+		Release:     release,
+		TriggeredBy: triggeredBy,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +141,225 @@ func FetchApplicationLogs(name, tail, instanceURL string) (*pb.LogResponse, erro
 	return res, nil
 }
 
+// CreateApplicationReplica is a remote procedure call for creating a replica of an application in a worker node
+func CreateApplicationReplica(name, instanceURL string) ([]byte, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.CreateReplica(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetData(), nil
+}
+
+// DeleteApplicationReplica is a remote procedure call for deleting a replica of an application in a worker node
+func DeleteApplicationReplica(name, instanceURL string) (*pb.DeletionResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.DeleteReplica(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// EvictApplication is a remote procedure call for removing an application which has been
+// moved to another worker node from its previous worker node
+func EvictApplication(name, instanceURL string) (*pb.DeletionResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Evict(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RelocateApplication is a remote procedure call for deploying an application which is moved from
+// another worker node on the given worker node with the commit and lifecycle state it had there
+func RelocateApplication(name, instanceURL string) ([]byte, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Relocate(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetData(), nil
+}
+
+// StartApplication is a remote procedure call for starting an application or its replica in a worker node
+func StartApplication(name, instanceURL string) (*pb.GenericResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Start(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// StopApplication is a remote procedure call for stopping an application or its replica in a worker node
+func StopApplication(name, instanceURL string) (*pb.GenericResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Stop(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RestartApplication is a remote procedure call for restarting an application or its replica in a worker node
+func RestartApplication(name, instanceURL string) (*pb.GenericResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Restart(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SleepApplication is a remote procedure call for putting an idle application or its replica to sleep in a worker node
+func SleepApplication(name, instanceURL string) (*pb.GenericResponse, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Sleep(ctx, &pb.NameHolder{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReconcileApplications is a remote procedure call for reconciling the application containers of a worker node
+// with the applications stored for it, the actions are only reported if dryRun is set
+func ReconcileApplications(dryRun bool, instanceURL string) ([]byte, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewApplicationFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Reconcile(ctx, &pb.ReconcileRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetData(), nil
+}
+
 // NewApplicationFactory returns a new GRPC server for creating applications
 func NewApplicationFactory(bindings pb.ApplicationFactoryServer) *grpc.Server {
 	srv := grpc.NewServer(
@@ -110,6 +110,31 @@ func ReloadDatabaseServer(language, instanceURL string) (*pb.GenericResponse, er
 	return res, nil
 }
 
+// ReconcileDatabases is a remote procedure call for reconciling the database containers of a worker node
+// with the databases stored for it, the actions are only reported if dryRun is set
+func ReconcileDatabases(dryRun bool, instanceURL string) ([]byte, error) {
+	conn, err := grpc.Dial(
+		instanceURL,
+		grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(authCredentials),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewDatabaseFactoryClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res, err := client.Reconcile(ctx, &pb.ReconcileRequest{DryRun: dryRun})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetData(), nil
+}
+
 // NewDatabaseFactory returns a new GRPC server for creating databases
 func NewDatabaseFactory(bindings pb.DatabaseFactoryServer) *grpc.Server {
 	srv := grpc.NewServer(
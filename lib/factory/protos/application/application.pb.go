@@ -157,6 +157,147 @@ func (m *NameHolder) GetName() string {
 	return ""
 }
 
+type RebuildRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TriggeredBy          string   `protobuf:"bytes,2,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildRequest) Reset()         { *m = RebuildRequest{} }
+func (m *RebuildRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildRequest) ProtoMessage()    {}
+func (*RebuildRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{3}
+}
+
+func (m *RebuildRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildRequest.Unmarshal(m, b)
+}
+func (m *RebuildRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildRequest.Marshal(b, m, deterministic)
+}
+func (m *RebuildRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildRequest.Merge(m, src)
+}
+func (m *RebuildRequest) XXX_Size() int {
+	return xxx_messageInfo_RebuildRequest.Size(m)
+}
+func (m *RebuildRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildRequest proto.InternalMessageInfo
+
+func (m *RebuildRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RebuildRequest) GetTriggeredBy() string {
+	if m != nil {
+		return m.TriggeredBy
+	}
+	return ""
+}
+
+type RollbackRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Release              string   `protobuf:"bytes,2,opt,name=release,proto3" json:"release,omitempty"`
+	TriggeredBy          string   `protobuf:"bytes,3,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackRequest) Reset()         { *m = RollbackRequest{} }
+func (m *RollbackRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackRequest) ProtoMessage()    {}
+func (*RollbackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{4}
+}
+
+func (m *RollbackRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackRequest.Unmarshal(m, b)
+}
+func (m *RollbackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackRequest.Marshal(b, m, deterministic)
+}
+func (m *RollbackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackRequest.Merge(m, src)
+}
+func (m *RollbackRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackRequest.Size(m)
+}
+func (m *RollbackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackRequest proto.InternalMessageInfo
+
+func (m *RollbackRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RollbackRequest) GetRelease() string {
+	if m != nil {
+		return m.Release
+	}
+	return ""
+}
+
+func (m *RollbackRequest) GetTriggeredBy() string {
+	if m != nil {
+		return m.TriggeredBy
+	}
+	return ""
+}
+
+type ReconcileRequest struct {
+	DryRun               bool     `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileRequest) Reset()         { *m = ReconcileRequest{} }
+func (m *ReconcileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileRequest) ProtoMessage()    {}
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{5}
+}
+
+func (m *ReconcileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileRequest.Unmarshal(m, b)
+}
+func (m *ReconcileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReconcileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileRequest.Merge(m, src)
+}
+func (m *ReconcileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconcileRequest.Size(m)
+}
+func (m *ReconcileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileRequest proto.InternalMessageInfo
+
+func (m *ReconcileRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type DeletionResponse struct {
 	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -168,7 +309,7 @@ func (m *DeletionResponse) Reset()         { *m = DeletionResponse{} }
 func (m *DeletionResponse) String() string { return proto.CompactTextString(m) }
 func (*DeletionResponse) ProtoMessage()    {}
 func (*DeletionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc846aced8fe6ea6, []int{3}
+	return fileDescriptor_fc846aced8fe6ea6, []int{6}
 }
 
 func (m *DeletionResponse) XXX_Unmarshal(b []byte) error {
@@ -196,6 +337,45 @@ func (m *DeletionResponse) GetSuccess() bool {
 	return false
 }
 
+type GenericResponse struct {
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenericResponse) Reset()         { *m = GenericResponse{} }
+func (m *GenericResponse) String() string { return proto.CompactTextString(m) }
+func (*GenericResponse) ProtoMessage()    {}
+func (*GenericResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{7}
+}
+
+func (m *GenericResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericResponse.Unmarshal(m, b)
+}
+func (m *GenericResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericResponse.Marshal(b, m, deterministic)
+}
+func (m *GenericResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericResponse.Merge(m, src)
+}
+func (m *GenericResponse) XXX_Size() int {
+	return xxx_messageInfo_GenericResponse.Size(m)
+}
+func (m *GenericResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericResponse proto.InternalMessageInfo
+
+func (m *GenericResponse) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
 type LogRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Tail                 string   `protobuf:"bytes,2,opt,name=tail,proto3" json:"tail,omitempty"`
@@ -208,7 +388,7 @@ func (m *LogRequest) Reset()         { *m = LogRequest{} }
 func (m *LogRequest) String() string { return proto.CompactTextString(m) }
 func (*LogRequest) ProtoMessage()    {}
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc846aced8fe6ea6, []int{4}
+	return fileDescriptor_fc846aced8fe6ea6, []int{8}
 }
 
 func (m *LogRequest) XXX_Unmarshal(b []byte) error {
@@ -255,7 +435,7 @@ func (m *LogResponse) Reset()         { *m = LogResponse{} }
 func (m *LogResponse) String() string { return proto.CompactTextString(m) }
 func (*LogResponse) ProtoMessage()    {}
 func (*LogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc846aced8fe6ea6, []int{5}
+	return fileDescriptor_fc846aced8fe6ea6, []int{9}
 }
 
 func (m *LogResponse) XXX_Unmarshal(b []byte) error {
@@ -294,7 +474,11 @@ func init() {
 	proto.RegisterType((*RequestBody)(nil), "application.RequestBody")
 	proto.RegisterType((*ResponseBody)(nil), "application.ResponseBody")
 	proto.RegisterType((*NameHolder)(nil), "application.NameHolder")
+	proto.RegisterType((*RebuildRequest)(nil), "application.RebuildRequest")
+	proto.RegisterType((*RollbackRequest)(nil), "application.RollbackRequest")
+	proto.RegisterType((*ReconcileRequest)(nil), "application.ReconcileRequest")
 	proto.RegisterType((*DeletionResponse)(nil), "application.DeletionResponse")
+	proto.RegisterType((*GenericResponse)(nil), "application.GenericResponse")
 	proto.RegisterType((*LogRequest)(nil), "application.LogRequest")
 	proto.RegisterType((*LogResponse)(nil), "application.LogResponse")
 }
@@ -302,27 +486,39 @@ func init() {
 func init() { proto.RegisterFile("application.proto", fileDescriptor_fc846aced8fe6ea6) }
 
 var fileDescriptor_fc846aced8fe6ea6 = []byte{
-	// 314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xcb, 0x4e, 0xc3, 0x30,
-	0x10, 0x6c, 0x5a, 0xe8, 0x63, 0xdb, 0x03, 0xac, 0x90, 0x30, 0x91, 0x90, 0x22, 0x9f, 0x7a, 0x40,
-	0x3d, 0x00, 0x37, 0x0e, 0x85, 0x82, 0x2a, 0x0e, 0x15, 0x07, 0xf3, 0x05, 0x6e, 0xb2, 0x0a, 0x91,
-	0xdc, 0x38, 0xc4, 0x8e, 0x50, 0xff, 0x85, 0x8f, 0x45, 0x71, 0x9b, 0x26, 0x2d, 0xaf, 0xdb, 0xac,
-	0x67, 0x76, 0x32, 0x3b, 0x0a, 0x9c, 0xca, 0x2c, 0x53, 0x49, 0x28, 0x6d, 0xa2, 0xd3, 0x49, 0x96,
-	0x6b, 0xab, 0x71, 0xd8, 0x78, 0xe2, 0xaf, 0x30, 0x14, 0xf4, 0x5e, 0x90, 0xb1, 0x33, 0x1d, 0xad,
-	0xd1, 0x87, 0xbe, 0x92, 0x69, 0x5c, 0xc8, 0x98, 0x98, 0x17, 0x78, 0xe3, 0x81, 0xd8, 0xcd, 0x78,
-	0x06, 0xc7, 0xfa, 0x23, 0xa5, 0x9c, 0xb5, 0x1d, 0xb1, 0x19, 0x10, 0xe1, 0x28, 0x92, 0x56, 0xb2,
-	0x4e, 0xe0, 0x8d, 0x47, 0xc2, 0x61, 0xce, 0x61, 0x24, 0xc8, 0x64, 0x3a, 0x35, 0xe4, 0x5c, 0x2b,
-	0x8d, 0xd7, 0xd0, 0x04, 0x00, 0x2f, 0x72, 0x45, 0xcf, 0x5a, 0x45, 0x1b, 0x97, 0x54, 0xae, 0xaa,
-	0x6f, 0x3a, 0xcc, 0xaf, 0xe0, 0xe4, 0x89, 0x14, 0x95, 0x31, 0x2b, 0x37, 0x64, 0xd0, 0x33, 0x45,
-	0x18, 0x92, 0x31, 0x4e, 0xda, 0x17, 0xd5, 0xc8, 0x6f, 0x01, 0x16, 0x3a, 0xde, 0xde, 0xf2, 0x93,
-	0x5f, 0xf9, 0x66, 0x65, 0xa2, 0xb6, 0xf1, 0x1d, 0xe6, 0x77, 0x30, 0x74, 0x5b, 0xff, 0xd9, 0xef,
-	0x4e, 0x68, 0x07, 0x9d, 0x72, 0xb9, 0xc4, 0xd7, 0x9f, 0x6d, 0xc0, 0x87, 0xba, 0xcb, 0xb9, 0x0c,
-	0xad, 0xce, 0xd7, 0x38, 0x85, 0xee, 0x63, 0x4e, 0xd2, 0x12, 0xb2, 0x49, 0xb3, 0xfd, 0x46, 0xcf,
-	0xfe, 0xc5, 0x01, 0x53, 0x97, 0xc5, 0x5b, 0x38, 0x83, 0xae, 0x3b, 0x9c, 0xf0, 0x7c, 0x4f, 0x56,
-	0xf7, 0xe5, 0x5f, 0xee, 0x11, 0x87, 0x35, 0xf1, 0x16, 0x4e, 0xa1, 0x27, 0x68, 0x59, 0x24, 0x2a,
-	0xfa, 0xdd, 0xe4, 0xcf, 0x10, 0xf7, 0x30, 0x98, 0x93, 0x0d, 0xdf, 0x16, 0x3a, 0x36, 0x07, 0x16,
-	0x75, 0xcf, 0x3e, 0xfb, 0x4e, 0x54, 0x11, 0x96, 0x5d, 0xf7, 0xbb, 0xdd, 0x7c, 0x05, 0x00, 0x00,
-	0xff, 0xff, 0xd0, 0x7b, 0xe0, 0x62, 0x83, 0x02, 0x00, 0x00,
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x6d, 0x9a, 0xef, 0x49, 0x4a, 0xcb, 0x0a, 0xa9, 0x26, 0x50, 0x29, 0xec, 0xa9, 0x52, 0x51,
+	0x0f, 0xc0, 0x8d, 0x43, 0xdb, 0x94, 0x34, 0x20, 0x55, 0x1c, 0x36, 0x3f, 0xa0, 0xda, 0xac, 0x47,
+	0xc6, 0x62, 0xeb, 0x35, 0xeb, 0x35, 0xc8, 0xff, 0x92, 0x9f, 0x84, 0xbc, 0xb1, 0x1d, 0xdb, 0x01,
+	0x07, 0xa5, 0xb7, 0x1d, 0xcf, 0xdb, 0x37, 0x33, 0x6f, 0xde, 0xca, 0xf0, 0x9c, 0x87, 0xa1, 0xf4,
+	0x05, 0x37, 0xbe, 0x0a, 0x2e, 0x43, 0xad, 0x8c, 0x22, 0xa3, 0xd2, 0x27, 0xba, 0x84, 0x11, 0xc3,
+	0x1f, 0x31, 0x46, 0x66, 0xa6, 0xdc, 0x84, 0x4c, 0x60, 0x20, 0x79, 0xe0, 0xc5, 0xdc, 0x43, 0xa7,
+	0x35, 0x6d, 0x9d, 0x0f, 0x59, 0x11, 0x93, 0x17, 0xd0, 0x55, 0xbf, 0x02, 0xd4, 0xce, 0xa1, 0x4d,
+	0xac, 0x03, 0x42, 0xa0, 0xe3, 0x72, 0xc3, 0x9d, 0xf6, 0xb4, 0x75, 0x3e, 0x66, 0xf6, 0x4c, 0x29,
+	0x8c, 0x19, 0x46, 0xa1, 0x0a, 0x22, 0xb4, 0xac, 0x39, 0xa6, 0x55, 0xc2, 0x4c, 0x01, 0xbe, 0xf2,
+	0x47, 0xfc, 0xac, 0xa4, 0xbb, 0x66, 0x09, 0xf8, 0x63, 0x5e, 0xd3, 0x9e, 0xe9, 0x02, 0x9e, 0x31,
+	0x5c, 0xc5, 0xbe, 0x74, 0xb3, 0x0e, 0xff, 0x86, 0x22, 0x6f, 0x60, 0x6c, 0xb4, 0xef, 0x79, 0xa8,
+	0xd1, 0x7d, 0x58, 0x25, 0x59, 0x73, 0xa3, 0xe2, 0xdb, 0x2c, 0xa1, 0x2b, 0x38, 0x66, 0x4a, 0xca,
+	0x15, 0x17, 0xdf, 0x9b, 0x98, 0x1c, 0xe8, 0x6b, 0x94, 0xc8, 0x23, 0xcc, 0x48, 0xf2, 0x70, 0xab,
+	0x46, 0x7b, 0xbb, 0xc6, 0x05, 0x9c, 0x30, 0x14, 0x2a, 0x10, 0xbe, 0xc4, 0xbc, 0xc8, 0x29, 0xf4,
+	0x5d, 0x9d, 0x3c, 0xe8, 0x38, 0xb0, 0x75, 0x06, 0xac, 0xe7, 0xea, 0x84, 0xc5, 0x01, 0x7d, 0x0b,
+	0x27, 0x9f, 0x50, 0x62, 0xba, 0x80, 0x5c, 0xa7, 0xb4, 0x7a, 0x14, 0x0b, 0x81, 0x51, 0x94, 0x81,
+	0xf3, 0x90, 0x5e, 0xc0, 0xf1, 0x02, 0x03, 0xd4, 0xbe, 0xf8, 0x0f, 0xf0, 0x07, 0x80, 0x7b, 0xe5,
+	0x35, 0x8d, 0x49, 0xa0, 0x63, 0xb8, 0x2f, 0xb3, 0x19, 0xed, 0x99, 0x7e, 0x84, 0x91, 0xbd, 0xb5,
+	0x8b, 0xbe, 0xd8, 0xe4, 0xe1, 0xb4, 0x9d, 0x5e, 0x4e, 0xcf, 0xef, 0x7e, 0xf7, 0x81, 0xdc, 0x6c,
+	0x2c, 0x75, 0xc7, 0x85, 0x51, 0x3a, 0x21, 0x57, 0xd0, 0xbb, 0xd5, 0xc8, 0x0d, 0x12, 0xe7, 0xb2,
+	0x6c, 0xc2, 0x92, 0xdd, 0x26, 0x2f, 0x6b, 0x99, 0x8d, 0x67, 0xe8, 0x01, 0x99, 0x41, 0xcf, 0xaa,
+	0x84, 0xe4, 0xb4, 0x02, 0xdb, 0xd8, 0x66, 0x72, 0x56, 0x49, 0xd4, 0x35, 0xa5, 0x07, 0xe4, 0x16,
+	0xfa, 0x99, 0x87, 0xc8, 0xab, 0x5a, 0xad, 0xb2, 0xb3, 0x9a, 0x1b, 0x99, 0xc3, 0x20, 0xf7, 0x0f,
+	0x79, 0x5d, 0x05, 0x56, 0x6d, 0xd5, 0x4c, 0x73, 0x0d, 0xc3, 0x3b, 0x34, 0xe2, 0xdb, 0xbd, 0xf2,
+	0xa2, 0xda, 0x48, 0x9b, 0x95, 0x4d, 0x9c, 0xed, 0x44, 0x31, 0xcd, 0x1c, 0x8e, 0xd6, 0x92, 0x32,
+	0xb4, 0x90, 0x7f, 0x0b, 0xd3, 0xd8, 0xc8, 0x17, 0x38, 0x5a, 0x0b, 0xbb, 0x93, 0x66, 0xa7, 0xbe,
+	0x37, 0xd0, 0x9d, 0xff, 0xf4, 0x85, 0x79, 0x02, 0xc5, 0x35, 0x0c, 0x18, 0x4a, 0x25, 0xb8, 0xc1,
+	0x3d, 0xe7, 0x59, 0xc0, 0xb0, 0x78, 0x7b, 0xe4, 0xac, 0x86, 0xac, 0xbe, 0xc9, 0x5d, 0x1b, 0xea,
+	0x2e, 0x0d, 0xd7, 0x0d, 0xd3, 0x54, 0xd7, 0x5f, 0x7b, 0x96, 0xf4, 0x80, 0x5c, 0x41, 0x67, 0x69,
+	0x54, 0xb8, 0x3f, 0xc1, 0x2c, 0x35, 0x6c, 0xf4, 0xb4, 0x26, 0xd2, 0x31, 0x24, 0xe2, 0xfe, 0x5d,
+	0xac, 0x7a, 0xf6, 0x4f, 0xf1, 0xfe, 0xcf, 0x00, 0xd7, 0x01, 0xca, 0x2b, 0x3e, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -339,8 +535,18 @@ const _ = grpc.SupportPackageIsVersion4
 type ApplicationFactoryClient interface {
 	Create(ctx context.Context, in *RequestBody, opts ...grpc.CallOption) (*ResponseBody, error)
 	Delete(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*DeletionResponse, error)
-	Rebuild(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error)
+	Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*ResponseBody, error)
+	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*ResponseBody, error)
 	FetchLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
+	CreateReplica(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error)
+	DeleteReplica(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*DeletionResponse, error)
+	Evict(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*DeletionResponse, error)
+	Relocate(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ResponseBody, error)
+	Start(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error)
+	Stop(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error)
+	Restart(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error)
+	Sleep(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error)
 }
 
 type applicationFactoryClient struct {
@@ -369,7 +575,7 @@ func (c *applicationFactoryClient) Delete(ctx context.Context, in *NameHolder, o
 	return out, nil
 }
 
-func (c *applicationFactoryClient) Rebuild(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error) {
+func (c *applicationFactoryClient) Rebuild(ctx context.Context, in *RebuildRequest, opts ...grpc.CallOption) (*ResponseBody, error) {
 	out := new(ResponseBody)
 	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Rebuild", in, out, opts...)
 	if err != nil {
@@ -378,6 +584,15 @@ func (c *applicationFactoryClient) Rebuild(ctx context.Context, in *NameHolder,
 	return out, nil
 }
 
+func (c *applicationFactoryClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*ResponseBody, error) {
+	out := new(ResponseBody)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationFactoryClient) FetchLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error) {
 	out := new(LogResponse)
 	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/FetchLogs", in, out, opts...)
@@ -387,12 +602,103 @@ func (c *applicationFactoryClient) FetchLogs(ctx context.Context, in *LogRequest
 	return out, nil
 }
 
+func (c *applicationFactoryClient) CreateReplica(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error) {
+	out := new(ResponseBody)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/CreateReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) DeleteReplica(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*DeletionResponse, error) {
+	out := new(DeletionResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/DeleteReplica", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Evict(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*DeletionResponse, error) {
+	out := new(DeletionResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Evict", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Relocate(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*ResponseBody, error) {
+	out := new(ResponseBody)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Relocate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ResponseBody, error) {
+	out := new(ResponseBody)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Start(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Start", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Stop(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Restart(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Restart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationFactoryClient) Sleep(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/application.ApplicationFactory/Sleep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApplicationFactoryServer is the server API for ApplicationFactory service.
 type ApplicationFactoryServer interface {
 	Create(context.Context, *RequestBody) (*ResponseBody, error)
 	Delete(context.Context, *NameHolder) (*DeletionResponse, error)
-	Rebuild(context.Context, *NameHolder) (*ResponseBody, error)
+	Rebuild(context.Context, *RebuildRequest) (*ResponseBody, error)
+	Rollback(context.Context, *RollbackRequest) (*ResponseBody, error)
 	FetchLogs(context.Context, *LogRequest) (*LogResponse, error)
+	CreateReplica(context.Context, *NameHolder) (*ResponseBody, error)
+	DeleteReplica(context.Context, *NameHolder) (*DeletionResponse, error)
+	Evict(context.Context, *NameHolder) (*DeletionResponse, error)
+	Relocate(context.Context, *NameHolder) (*ResponseBody, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ResponseBody, error)
+	Start(context.Context, *NameHolder) (*GenericResponse, error)
+	Stop(context.Context, *NameHolder) (*GenericResponse, error)
+	Restart(context.Context, *NameHolder) (*GenericResponse, error)
+	Sleep(context.Context, *NameHolder) (*GenericResponse, error)
 }
 
 // UnimplementedApplicationFactoryServer can be embedded to have forward compatible implementations.
@@ -405,12 +711,42 @@ func (*UnimplementedApplicationFactoryServer) Create(ctx context.Context, req *R
 func (*UnimplementedApplicationFactoryServer) Delete(ctx context.Context, req *NameHolder) (*DeletionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
-func (*UnimplementedApplicationFactoryServer) Rebuild(ctx context.Context, req *NameHolder) (*ResponseBody, error) {
+func (*UnimplementedApplicationFactoryServer) Rebuild(ctx context.Context, req *RebuildRequest) (*ResponseBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rebuild not implemented")
 }
+func (*UnimplementedApplicationFactoryServer) Rollback(ctx context.Context, req *RollbackRequest) (*ResponseBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
 func (*UnimplementedApplicationFactoryServer) FetchLogs(ctx context.Context, req *LogRequest) (*LogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchLogs not implemented")
 }
+func (*UnimplementedApplicationFactoryServer) CreateReplica(ctx context.Context, req *NameHolder) (*ResponseBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReplica not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) DeleteReplica(ctx context.Context, req *NameHolder) (*DeletionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReplica not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Evict(ctx context.Context, req *NameHolder) (*DeletionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evict not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Relocate(ctx context.Context, req *NameHolder) (*ResponseBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Relocate not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Reconcile(ctx context.Context, req *ReconcileRequest) (*ResponseBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Start(ctx context.Context, req *NameHolder) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Stop(ctx context.Context, req *NameHolder) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Restart(ctx context.Context, req *NameHolder) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
+}
+func (*UnimplementedApplicationFactoryServer) Sleep(ctx context.Context, req *NameHolder) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sleep not implemented")
+}
 
 func RegisterApplicationFactoryServer(s *grpc.Server, srv ApplicationFactoryServer) {
 	s.RegisterService(&_ApplicationFactory_serviceDesc, srv)
@@ -453,7 +789,7 @@ func _ApplicationFactory_Delete_Handler(srv interface{}, ctx context.Context, de
 }
 
 func _ApplicationFactory_Rebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NameHolder)
+	in := new(RebuildRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -465,7 +801,25 @@ func _ApplicationFactory_Rebuild_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: "/application.ApplicationFactory/Rebuild",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApplicationFactoryServer).Rebuild(ctx, req.(*NameHolder))
+		return srv.(ApplicationFactoryServer).Rebuild(ctx, req.(*RebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Rollback(ctx, req.(*RollbackRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -488,6 +842,168 @@ func _ApplicationFactory_FetchLogs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationFactory_CreateReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).CreateReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/CreateReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).CreateReplica(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_DeleteReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).DeleteReplica(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/DeleteReplica",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).DeleteReplica(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Evict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Evict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Evict",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Evict(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Relocate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Relocate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Relocate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Relocate(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Start",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Start(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Stop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Stop(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Restart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Restart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Restart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Restart(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationFactory_Sleep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NameHolder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationFactoryServer).Sleep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/application.ApplicationFactory/Sleep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationFactoryServer).Sleep(ctx, req.(*NameHolder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApplicationFactory_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "application.ApplicationFactory",
 	HandlerType: (*ApplicationFactoryServer)(nil),
@@ -504,10 +1020,50 @@ var _ApplicationFactory_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rebuild",
 			Handler:    _ApplicationFactory_Rebuild_Handler,
 		},
+		{
+			MethodName: "Rollback",
+			Handler:    _ApplicationFactory_Rollback_Handler,
+		},
 		{
 			MethodName: "FetchLogs",
 			Handler:    _ApplicationFactory_FetchLogs_Handler,
 		},
+		{
+			MethodName: "CreateReplica",
+			Handler:    _ApplicationFactory_CreateReplica_Handler,
+		},
+		{
+			MethodName: "DeleteReplica",
+			Handler:    _ApplicationFactory_DeleteReplica_Handler,
+		},
+		{
+			MethodName: "Evict",
+			Handler:    _ApplicationFactory_Evict_Handler,
+		},
+		{
+			MethodName: "Relocate",
+			Handler:    _ApplicationFactory_Relocate_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _ApplicationFactory_Reconcile_Handler,
+		},
+		{
+			MethodName: "Start",
+			Handler:    _ApplicationFactory_Start_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _ApplicationFactory_Stop_Handler,
+		},
+		{
+			MethodName: "Restart",
+			Handler:    _ApplicationFactory_Restart_Handler,
+		},
+		{
+			MethodName: "Sleep",
+			Handler:    _ApplicationFactory_Sleep_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "application.proto",
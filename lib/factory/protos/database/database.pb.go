@@ -196,6 +196,45 @@ func (m *LanguageHolder) GetLanguage() string {
 	return ""
 }
 
+type ReconcileRequest struct {
+	DryRun               bool     `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileRequest) Reset()         { *m = ReconcileRequest{} }
+func (m *ReconcileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileRequest) ProtoMessage()    {}
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b90fe3356ea5df07, []int{4}
+}
+
+func (m *ReconcileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileRequest.Unmarshal(m, b)
+}
+func (m *ReconcileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReconcileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileRequest.Merge(m, src)
+}
+func (m *ReconcileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconcileRequest.Size(m)
+}
+func (m *ReconcileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileRequest proto.InternalMessageInfo
+
+func (m *ReconcileRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type GenericResponse struct {
 	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -207,7 +246,7 @@ func (m *GenericResponse) Reset()         { *m = GenericResponse{} }
 func (m *GenericResponse) String() string { return proto.CompactTextString(m) }
 func (*GenericResponse) ProtoMessage()    {}
 func (*GenericResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b90fe3356ea5df07, []int{4}
+	return fileDescriptor_b90fe3356ea5df07, []int{5}
 }
 
 func (m *GenericResponse) XXX_Unmarshal(b []byte) error {
@@ -247,7 +286,7 @@ func (m *LogRequest) Reset()         { *m = LogRequest{} }
 func (m *LogRequest) String() string { return proto.CompactTextString(m) }
 func (*LogRequest) ProtoMessage()    {}
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b90fe3356ea5df07, []int{5}
+	return fileDescriptor_b90fe3356ea5df07, []int{6}
 }
 
 func (m *LogRequest) XXX_Unmarshal(b []byte) error {
@@ -294,7 +333,7 @@ func (m *LogResponse) Reset()         { *m = LogResponse{} }
 func (m *LogResponse) String() string { return proto.CompactTextString(m) }
 func (*LogResponse) ProtoMessage()    {}
 func (*LogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b90fe3356ea5df07, []int{6}
+	return fileDescriptor_b90fe3356ea5df07, []int{7}
 }
 
 func (m *LogResponse) XXX_Unmarshal(b []byte) error {
@@ -334,6 +373,7 @@ func init() {
 	proto.RegisterType((*ResponseBody)(nil), "database.ResponseBody")
 	proto.RegisterType((*NameHolder)(nil), "database.NameHolder")
 	proto.RegisterType((*LanguageHolder)(nil), "database.LanguageHolder")
+	proto.RegisterType((*ReconcileRequest)(nil), "database.ReconcileRequest")
 	proto.RegisterType((*GenericResponse)(nil), "database.GenericResponse")
 	proto.RegisterType((*LogRequest)(nil), "database.LogRequest")
 	proto.RegisterType((*LogResponse)(nil), "database.LogResponse")
@@ -342,28 +382,30 @@ func init() {
 func init() { proto.RegisterFile("database.proto", fileDescriptor_b90fe3356ea5df07) }
 
 var fileDescriptor_b90fe3356ea5df07 = []byte{
-	// 325 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0x4f, 0x4f, 0xfa, 0x40,
-	0x10, 0xa5, 0xc0, 0xaf, 0x3f, 0x18, 0x08, 0x24, 0x13, 0x30, 0xb5, 0xa7, 0x66, 0x4f, 0x24, 0x1a,
-	0x0e, 0x7a, 0x52, 0x4c, 0x4c, 0x94, 0xa0, 0x87, 0xc6, 0xc3, 0xfa, 0x09, 0x96, 0x76, 0x52, 0x49,
-	0x4a, 0x17, 0xbb, 0xdb, 0x18, 0xbe, 0x80, 0x9f, 0xdb, 0x74, 0xe9, 0x3f, 0x39, 0xd4, 0xdb, 0xcc,
-	0xf4, 0xbd, 0x79, 0x7d, 0x6f, 0x16, 0x26, 0xa1, 0xd0, 0x62, 0x2b, 0x14, 0x2d, 0x0f, 0xa9, 0xd4,
-	0x12, 0x07, 0x65, 0xcf, 0xde, 0x61, 0xc4, 0xe9, 0x33, 0x23, 0xa5, 0x9f, 0x64, 0x78, 0x44, 0x17,
-	0x06, 0xb1, 0x48, 0xa2, 0x4c, 0x44, 0xe4, 0x58, 0x9e, 0xb5, 0x18, 0xf2, 0xaa, 0xc7, 0x19, 0xfc,
-	0x93, 0x5f, 0x09, 0xa5, 0x4e, 0xd7, 0x7c, 0x38, 0x35, 0x88, 0xd0, 0xcf, 0x97, 0x39, 0x3d, 0xcf,
-	0x5a, 0x8c, 0xb9, 0xa9, 0x19, 0x83, 0x31, 0x27, 0x75, 0x90, 0x89, 0x22, 0xb3, 0xb5, 0xc4, 0x58,
-	0x0d, 0x8c, 0x07, 0xf0, 0x26, 0xf6, 0xf4, 0x2a, 0xe3, 0xf0, 0xb4, 0x25, 0x11, 0xfb, 0x52, 0xd3,
-	0xd4, 0xec, 0x1a, 0x26, 0x7e, 0xa1, 0x5d, 0xa0, 0x5a, 0xfe, 0x8e, 0x5d, 0xc1, 0xf4, 0x85, 0x12,
-	0x4a, 0x77, 0x41, 0x29, 0x8d, 0x0e, 0xfc, 0x57, 0x59, 0x10, 0x90, 0x52, 0x06, 0x3d, 0xe0, 0x65,
-	0xcb, 0x1e, 0x00, 0x7c, 0x19, 0x15, 0xc6, 0x5b, 0x4d, 0x23, 0xf4, 0xb5, 0xd8, 0xc5, 0x85, 0x67,
-	0x53, 0xb3, 0x15, 0x8c, 0x0c, 0xfb, 0x2f, 0x99, 0xca, 0x77, 0xd7, 0xeb, 0xe5, 0xe4, 0xbc, 0xbe,
-	0xf9, 0xee, 0xc2, 0x74, 0x5d, 0xa4, 0xbf, 0x11, 0x81, 0x96, 0xe9, 0x11, 0xef, 0xc0, 0x7e, 0x4e,
-	0x49, 0x68, 0xc2, 0xf9, 0xb2, 0xba, 0x54, 0xe3, 0x2c, 0xee, 0x45, 0x73, 0x5c, 0x07, 0xcb, 0x3a,
-	0xb8, 0x02, 0x7b, 0x4d, 0x31, 0x69, 0xc2, 0x59, 0x8d, 0xa9, 0x83, 0x75, 0x2f, 0xeb, 0xe9, 0x59,
-	0x3c, 0xac, 0x83, 0xf7, 0x30, 0xdc, 0x90, 0x0e, 0x3e, 0x7c, 0x19, 0xa9, 0x26, 0xbf, 0xce, 0xc6,
-	0x9d, 0x9f, 0x4d, 0x2b, 0xee, 0x23, 0xd8, 0x9c, 0x62, 0x29, 0x42, 0x74, 0x1a, 0x90, 0x5f, 0xf7,
-	0x6a, 0x15, 0xdf, 0xda, 0xe6, 0x29, 0xde, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0xea, 0xa9, 0x3a,
-	0x16, 0x9c, 0x02, 0x00, 0x00,
+	// 365 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x6e, 0xe2, 0x30,
+	0x10, 0xc6, 0x09, 0xb0, 0x01, 0x06, 0x04, 0x2b, 0x0b, 0x76, 0xb3, 0x39, 0x45, 0x3e, 0x21, 0xb1,
+	0xe2, 0xb0, 0x7b, 0xda, 0xa5, 0x52, 0xd5, 0x16, 0xd1, 0x1e, 0x50, 0x0f, 0xee, 0x03, 0x54, 0x26,
+	0x19, 0xa5, 0x48, 0xc1, 0xa6, 0xb6, 0xa3, 0x2a, 0x8f, 0xd8, 0xb7, 0xaa, 0x30, 0xf9, 0x07, 0x52,
+	0xe9, 0x6d, 0x66, 0xf2, 0xcd, 0x8c, 0xe7, 0xfb, 0x05, 0x86, 0x11, 0x37, 0x7c, 0xc3, 0x35, 0xce,
+	0xf7, 0x4a, 0x1a, 0x49, 0xba, 0x45, 0x4e, 0x9f, 0xa0, 0xcf, 0xf0, 0x35, 0x45, 0x6d, 0x6e, 0x65,
+	0x94, 0x11, 0x1f, 0xba, 0x09, 0x17, 0x71, 0xca, 0x63, 0xf4, 0x9c, 0xc0, 0x99, 0xf6, 0x58, 0x99,
+	0x93, 0x31, 0x7c, 0x93, 0x6f, 0x02, 0x95, 0xd7, 0xb4, 0x1f, 0x8e, 0x09, 0x21, 0xd0, 0x3e, 0x0c,
+	0xf3, 0x5a, 0x81, 0x33, 0x1d, 0x30, 0x1b, 0x53, 0x0a, 0x03, 0x86, 0x7a, 0x2f, 0x85, 0x46, 0x3b,
+	0xb5, 0xd0, 0x38, 0x35, 0x4d, 0x00, 0xf0, 0xc8, 0x77, 0xf8, 0x20, 0x93, 0xe8, 0x38, 0x45, 0xf0,
+	0x5d, 0xb1, 0xd3, 0xc6, 0xf4, 0x37, 0x0c, 0xd7, 0xf9, 0xee, 0x5c, 0x75, 0xe1, 0x75, 0x74, 0x06,
+	0xdf, 0x19, 0x86, 0x52, 0x84, 0xdb, 0x04, 0xf3, 0x8b, 0xc8, 0x4f, 0xe8, 0x44, 0x2a, 0x7b, 0x56,
+	0xa9, 0xb0, 0xf2, 0x2e, 0x73, 0x23, 0x95, 0xb1, 0x54, 0xd0, 0x19, 0x8c, 0xee, 0x51, 0xa0, 0xda,
+	0x86, 0xc5, 0x3b, 0x89, 0x07, 0x1d, 0x9d, 0x86, 0x21, 0x6a, 0x9d, 0x6b, 0x8b, 0x94, 0x5e, 0x01,
+	0xac, 0x65, 0x5c, 0xcc, 0xbc, 0xe4, 0x10, 0x81, 0xb6, 0xe1, 0xdb, 0x24, 0x37, 0xc8, 0xc6, 0x74,
+	0x01, 0x7d, 0xdb, 0xfd, 0xd5, 0x9a, 0xd2, 0xa4, 0x66, 0xd0, 0x3a, 0x34, 0x1f, 0xe2, 0x3f, 0xef,
+	0x4d, 0x18, 0x2d, 0x73, 0x54, 0x2b, 0x1e, 0x1a, 0xa9, 0x32, 0xf2, 0x0f, 0xdc, 0x3b, 0x85, 0xdc,
+	0x20, 0x99, 0xcc, 0x4b, 0xac, 0x35, 0x86, 0xfe, 0x8f, 0x7a, 0xb9, 0xa2, 0x40, 0x1b, 0x64, 0x01,
+	0xee, 0x12, 0x13, 0x34, 0x48, 0xc6, 0x95, 0xa6, 0xa2, 0xe0, 0xff, 0xaa, 0xaa, 0x67, 0xf6, 0xd0,
+	0x06, 0xf9, 0x0f, 0xbd, 0x15, 0x9a, 0xf0, 0x65, 0x2d, 0x63, 0x5d, 0xef, 0xaf, 0xbc, 0xf1, 0x27,
+	0x67, 0xd5, 0xb2, 0xf7, 0x1a, 0x5c, 0x86, 0x89, 0xe4, 0x11, 0xf1, 0x6a, 0x92, 0x13, 0xb8, 0x97,
+	0x97, 0xdf, 0x40, 0xaf, 0xa4, 0x4b, 0xfc, 0xfa, 0x81, 0xa7, 0xc8, 0x3f, 0x3f, 0x7e, 0xe3, 0xda,
+	0x5f, 0xff, 0xef, 0xc7, 0x00, 0xc0, 0xde, 0xb1, 0x40, 0x0c, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -382,6 +424,7 @@ type DatabaseFactoryClient interface {
 	Delete(ctx context.Context, in *NameHolder, opts ...grpc.CallOption) (*GenericResponse, error)
 	FetchLogs(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (*LogResponse, error)
 	Reload(ctx context.Context, in *LanguageHolder, opts ...grpc.CallOption) (*GenericResponse, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ResponseBody, error)
 }
 
 type databaseFactoryClient struct {
@@ -428,12 +471,22 @@ func (c *databaseFactoryClient) Reload(ctx context.Context, in *LanguageHolder,
 	return out, nil
 }
 
+func (c *databaseFactoryClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ResponseBody, error) {
+	out := new(ResponseBody)
+	err := c.cc.Invoke(ctx, "/database.DatabaseFactory/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseFactoryServer is the server API for DatabaseFactory service.
 type DatabaseFactoryServer interface {
 	Create(context.Context, *RequestBody) (*ResponseBody, error)
 	Delete(context.Context, *NameHolder) (*GenericResponse, error)
 	FetchLogs(context.Context, *LogRequest) (*LogResponse, error)
 	Reload(context.Context, *LanguageHolder) (*GenericResponse, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ResponseBody, error)
 }
 
 // UnimplementedDatabaseFactoryServer can be embedded to have forward compatible implementations.
@@ -452,6 +505,9 @@ func (*UnimplementedDatabaseFactoryServer) FetchLogs(ctx context.Context, req *L
 func (*UnimplementedDatabaseFactoryServer) Reload(ctx context.Context, req *LanguageHolder) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reload not implemented")
 }
+func (*UnimplementedDatabaseFactoryServer) Reconcile(ctx context.Context, req *ReconcileRequest) (*ResponseBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
 
 func RegisterDatabaseFactoryServer(s *grpc.Server, srv DatabaseFactoryServer) {
 	s.RegisterService(&_DatabaseFactory_serviceDesc, srv)
@@ -529,6 +585,24 @@ func _DatabaseFactory_Reload_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DatabaseFactory_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseFactoryServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/database.DatabaseFactory/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseFactoryServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DatabaseFactory_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "database.DatabaseFactory",
 	HandlerType: (*DatabaseFactoryServer)(nil),
@@ -549,6 +623,10 @@ var _DatabaseFactory_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Reload",
 			Handler:    _DatabaseFactory_Reload_Handler,
 		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _DatabaseFactory_Reconcile_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "database.proto",
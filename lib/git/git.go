@@ -1,18 +1,17 @@
 package git
 
 import (
-	"fmt"
-
 	gogit "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
 )
 
-// Clone clones the repo from the url into the destination
-func Clone(url, branch, destination string) error {
+// Clone clones the reference (branch or tag) of the repo from the url into the destination
+func Clone(url, reference, destination string) error {
 	_, err := gogit.PlainClone(destination, false, &gogit.CloneOptions{
 		URL:           url,
-		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
+		ReferenceName: plumbing.ReferenceName(reference),
 		SingleBranch:  true,
 		Depth:         1,
 	})
@@ -21,21 +20,68 @@ func Clone(url, branch, destination string) error {
 
 // CloneWithToken clones the repo from the url using access token
 // which is an alternative for auth to clone private repositories
-func CloneWithToken(url, branch, destination, token string) error {
+func CloneWithToken(url, reference, destination, token string) error {
 	_, err := gogit.PlainClone(destination, false, &gogit.CloneOptions{
 		URL:           url,
-		ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch)),
+		ReferenceName: plumbing.ReferenceName(reference),
+		SingleBranch:  true,
+		Depth:         1,
+		Auth:          TokenAuth(token),
+	})
+	return err
+}
+
+// CloneWithKey clones the repo from the url over SSH using a PEM encoded private key
+// which is an alternative for auth to clone private repositories
+func CloneWithKey(url, reference, destination string, privateKey []byte) error {
+	auth, err := KeyAuth(url, privateKey)
+	if err != nil {
+		return err
+	}
+	_, err = gogit.PlainClone(destination, false, &gogit.CloneOptions{
+		URL:           url,
+		ReferenceName: plumbing.ReferenceName(reference),
 		SingleBranch:  true,
 		Depth:         1,
-		Auth: &http.BasicAuth{
-			// Since cloning through token username can be anything but not an empty string
-			Username: "gasper",
-			Password: token,
-		},
+		Auth:          auth,
 	})
 	return err
 }
 
+// TokenAuth returns the auth method for accessing a repository over HTTPS using an access token
+// nil is returned for an empty access token
+func TokenAuth(token string) transport.AuthMethod {
+	if token == "" {
+		return nil
+	}
+	return &http.BasicAuth{
+		// Since cloning through token username can be anything but not an empty string
+		Username: "gasper",
+		Password: token,
+	}
+}
+
+// CloneAtCommit clones the history of the reference (branch or tag) of the repo from the url into
+// the destination and checks out the given commit, auth can be nil for public repositories
+func CloneAtCommit(url, reference, commit, destination string, auth transport.AuthMethod) error {
+	repo, err := gogit.PlainClone(destination, false, &gogit.CloneOptions{
+		URL:           url,
+		ReferenceName: plumbing.ReferenceName(reference),
+		SingleBranch:  true,
+		Auth:          auth,
+	})
+	if err != nil {
+		return err
+	}
+	wtree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return wtree.Checkout(&gogit.CheckoutOptions{
+		Hash: plumbing.NewHash(commit),
+	})
+}
+
 // Pull pulls the latest branch from "origin"
 // 'dotgitPath' is the absolute path to .git directory
 // 'branch' is the branch name which is to be pulled
@@ -60,3 +106,17 @@ func Pull(dotgitPath, branch string) error {
 	}
 	return err
 }
+
+// HeadCommit returns the hash of the commit currently checked out in the repository
+// 'repoPath' is the absolute path to the cloned repository
+func HeadCommit(repoPath string) (string, error) {
+	repo, err := gogit.PlainOpen(repoPath)
+	if err != nil {
+		return "", err
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
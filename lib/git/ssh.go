@@ -0,0 +1,69 @@
+package git
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	gitssh "gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+// defaultSSHUser is the user used for SSH when the repository URL doesn't specify one
+const defaultSSHUser = "git"
+
+// IsSSHURL checks whether the repository URL is to be accessed over SSH
+// which is the case for `ssh://` URLs and scp-like `git@host:repo` URLs
+func IsSSHURL(url string) bool {
+	endpoint, err := transport.NewEndpoint(url)
+	return err == nil && endpoint.Protocol == "ssh"
+}
+
+// GenerateDeployKey generates an ed25519 key pair and returns the PEM encoded
+// private key along with the public key in the authorized keys format
+func GenerateDeployKey() ([]byte, string, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	})
+	publicKey, err := PublicDeployKey(privateKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return privateKey, publicKey, nil
+}
+
+// PublicDeployKey returns the public key in the authorized keys format of a PEM encoded private key
+// An error is returned if the private key is invalid or protected by a passphrase
+func PublicDeployKey(privateKey []byte) (string, error) {
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))), nil
+}
+
+// KeyAuth returns the auth method for accessing a repository over SSH using a PEM encoded private key
+// The host keys of the git server are verified against the known hosts of the current user
+func KeyAuth(url string, privateKey []byte) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, err
+	}
+	user := endpoint.User
+	if user == "" {
+		user = defaultSSHUser
+	}
+	return gitssh.NewPublicKeys(user, privateKey, "")
+}
@@ -30,6 +30,39 @@ const (
 	// MetricsCollection is the collection to hold the metrics of the instances
 	MetricsCollection = "metrics"
 
+	// TrafficCollection is the collection to hold the per minute aggregates of the requests to the applications
+	TrafficCollection = "traffic"
+
+	// BuildCollection is the collection to hold the build records of the applications
+	BuildCollection = "builds"
+
+	// WebhookCollection is the collection to hold the webhook secrets of the applications
+	WebhookCollection = "webhooks"
+
+	// WebhookDeliveryCollection is the collection to hold the webhook deliveries of the applications
+	WebhookDeliveryCollection = "webhook_deliveries"
+
+	// ReleaseCollection is the collection to hold the releases of the applications
+	ReleaseCollection = "releases"
+
+	// DeployKeyCollection is the collection to hold the deploy keys of the applications
+	DeployKeyCollection = "deploy_keys"
+
+	// ReplicaCollection is the collection to hold the replicas of the applications
+	ReplicaCollection = "replicas"
+
+	// DomainCollection is the collection to hold the custom domains of the applications
+	DomainCollection = "domains"
+
+	// CertificateCollection is the collection to hold the certificates of custom domains obtained over ACME
+	CertificateCollection = "certificates"
+
+	// RouteCollection is the collection to hold the path based routes of the applications
+	RouteCollection = "routes"
+
+	// NodeLabelsCollection is the collection to hold the labels attached to nodes by admins
+	NodeLabelsCollection = "node_labels"
+
 	// NameKey is the key holding the name of an instance
 	NameKey = "name"
 
@@ -66,6 +99,58 @@ const (
 	// TimestampKey is the key holding the timestamp of when a metrics collection was inserted
 	TimestampKey = "timestamp"
 
+	// StateKey is the key holding the lifecycle state of an application
+	StateKey = "state"
+
+	// RebuildStateKey is the key holding the lifecycle state of the deployment built by the latest
+	// rebuild of an application
+	RebuildStateKey = "rebuild_state"
+
+	// BuildIDKey is the key holding the ID of an application's build
+	BuildIDKey = "id"
+
+	// StartedAtKey is the key holding the timestamp of when an application's build was started
+	StartedAtKey = "started_at"
+
+	// DeliveryIDKey is the key holding the ID of an application's webhook delivery
+	DeliveryIDKey = "id"
+
+	// ReleaseIDKey is the key holding the ID of an application's release
+	ReleaseIDKey = "id"
+
+	// NodeKey is the key holding the URL of the node on which an application's replica is deployed
+	NodeKey = "node"
+
+	// CreatedAtKey is the key holding the timestamp of when an application's replica was created
+	CreatedAtKey = "created_at"
+
+	// ResourcesKey is the key holding the resources requested by an application
+	ResourcesKey = "resources"
+
+	// ReplicasKey is the key holding the number of replicas an application is scaled to
+	ReplicasKey = "replicas"
+
+	// HostnameKey is the key holding the hostname of an application's custom domain
+	HostnameKey = "hostname"
+
+	// PathKey is the key holding the path prefix of a route
+	PathKey = "path"
+
+	// TargetKey is the key holding the application served by a route
+	TargetKey = "target"
+
+	// HTTPSKey is the key holding the HTTPS policy of an application
+	HTTPSKey = "https"
+
+	// AccessKey is the key holding the access policy of an application
+	AccessKey = "access"
+
+	// IdleTimeoutKey is the key holding the time without requests after which an application is put to sleep
+	IdleTimeoutKey = "idle_timeout"
+
+	// GitKey is the key holding the git repository of an application
+	GitKey = "git"
+
 	//GctlUUIDKey is the key holding a unique key for authentication of user by jwt
 	GctlUUIDKey = "gctl_uuid"
 )
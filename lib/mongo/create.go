@@ -49,3 +49,25 @@ func RegisterMetrics(data interface{}) (interface{}, error) {
 func BulkRegisterMetrics(data []interface{}) ([]interface{}, error) {
 	return InsertMany(MetricsCollection, data)
 }
+
+// BulkRegisterTraffic is an abstraction over InsertMany which inserts multiple
+// traffic aggregates documents into the mongoDB
+func BulkRegisterTraffic(data []interface{}) ([]interface{}, error) {
+	return InsertMany(TrafficCollection, data)
+}
+
+// RegisterBuild is an abstraction over InsertOne which inserts an application's build record into the mongoDB
+func RegisterBuild(data interface{}) (interface{}, error) {
+	return InsertOne(BuildCollection, data)
+}
+
+// RegisterWebhookDelivery is an abstraction over InsertOne which inserts an application's
+// webhook delivery record into the mongoDB
+func RegisterWebhookDelivery(data interface{}) (interface{}, error) {
+	return InsertOne(WebhookDeliveryCollection, data)
+}
+
+// RegisterRelease is an abstraction over InsertOne which inserts an application's release into the mongoDB
+func RegisterRelease(data interface{}) (interface{}, error) {
+	return InsertOne(ReleaseCollection, data)
+}
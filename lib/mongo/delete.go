@@ -15,6 +15,14 @@ func DeleteOne(collectionName string, filter types.M) (interface{}, error) {
 	return collection.DeleteOne(ctx, filter)
 }
 
+// DeleteMany deletes multiple documents from a mongoDB collection
+func DeleteMany(collectionName string, filter types.M) (interface{}, error) {
+	collection := link.Collection(collectionName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return collection.DeleteMany(ctx, filter)
+}
+
 // DeleteInstance is an abstraction over DeleteOne which deletes an application from mongoDB
 func DeleteInstance(filter types.M) (interface{}, error) {
 	return DeleteOne(InstanceCollection, filter)
@@ -29,3 +37,59 @@ func DeleteUser(filter types.M) (interface{}, error) {
 func DeleteMetrics(filter types.M) (interface{}, error) {
 	return DeleteOne(MetricsCollection, filter)
 }
+
+// DeleteTraffic is an abstraction over DeleteMany which deletes the traffic aggregates of applications from mongoDB
+func DeleteTraffic(filter types.M) (interface{}, error) {
+	return DeleteMany(TrafficCollection, filter)
+}
+
+// DeleteBuilds is an abstraction over DeleteMany which deletes the build records of an application from mongoDB
+func DeleteBuilds(filter types.M) (interface{}, error) {
+	return DeleteMany(BuildCollection, filter)
+}
+
+// DeleteWebhook is an abstraction over DeleteOne which deletes the webhook of an application from mongoDB
+func DeleteWebhook(filter types.M) (interface{}, error) {
+	return DeleteOne(WebhookCollection, filter)
+}
+
+// DeleteWebhookDeliveries is an abstraction over DeleteMany which deletes the webhook
+// deliveries of an application from mongoDB
+func DeleteWebhookDeliveries(filter types.M) (interface{}, error) {
+	return DeleteMany(WebhookDeliveryCollection, filter)
+}
+
+// DeleteReleases is an abstraction over DeleteMany which deletes the releases of an application from mongoDB
+func DeleteReleases(filter types.M) (interface{}, error) {
+	return DeleteMany(ReleaseCollection, filter)
+}
+
+// DeleteReplicas is an abstraction over DeleteMany which deletes the replicas of applications from mongoDB
+func DeleteReplicas(filter types.M) (interface{}, error) {
+	return DeleteMany(ReplicaCollection, filter)
+}
+
+// DeleteNodeLabels is an abstraction over DeleteOne which deletes the labels attached to a node from mongoDB
+func DeleteNodeLabels(filter types.M) (interface{}, error) {
+	return DeleteOne(NodeLabelsCollection, filter)
+}
+
+// DeleteDomain is an abstraction over DeleteOne which deletes a custom domain of an application from mongoDB
+func DeleteDomain(filter types.M) (interface{}, error) {
+	return DeleteOne(DomainCollection, filter)
+}
+
+// DeleteRoutes is an abstraction over DeleteMany which deletes the path based routes of applications from mongoDB
+func DeleteRoutes(filter types.M) (interface{}, error) {
+	return DeleteMany(RouteCollection, filter)
+}
+
+// DeleteCertificate is an abstraction over DeleteOne which deletes the certificate of a custom domain from mongoDB
+func DeleteCertificate(filter types.M) (interface{}, error) {
+	return DeleteOne(CertificateCollection, filter)
+}
+
+// DeleteDeployKey is an abstraction over DeleteOne which deletes the deploy key of an application from mongoDB
+func DeleteDeployKey(filter types.M) (interface{}, error) {
+	return DeleteOne(DeployKeyCollection, filter)
+}
@@ -160,6 +160,264 @@ func FetchContainerMetrics(filter types.M, count int64) []types.M {
 	return FetchDocs(MetricsCollection, filter, options)
 }
 
+// FetchTraffic returns the per minute aggregates of the requests to applications sorted by their timestamps
+func FetchTraffic(filter types.M) ([]*types.Traffic, error) {
+	collection := link.Collection(TrafficCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetSort(types.M{TimestampKey: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	traffic := make([]*types.Traffic, 0)
+	for cur.Next(ctx) {
+		record := &types.Traffic{}
+		if err := cur.Decode(record); err != nil {
+			return nil, err
+		}
+		traffic = append(traffic, record)
+	}
+	return traffic, cur.Err()
+}
+
+// FetchBuilds is an abstraction over FetchDocs for retrieving the build records of an application
+// The output of the build commands is omitted, latest builds are returned first
+func FetchBuilds(filter types.M) []types.M {
+	return FetchDocs(
+		BuildCollection,
+		filter,
+		options.Find().
+			SetSort(types.M{StartedAtKey: -1}).
+			SetProjection(types.M{"steps.output": 0}),
+	)
+}
+
+// FetchSingleBuild returns a build record of an application along with the output of its commands
+func FetchSingleBuild(name, id string) (*types.Build, error) {
+	collection := link.Collection(BuildCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	build := &types.Build{}
+	err := collection.FindOne(ctx, types.M{
+		NameKey:    name,
+		BuildIDKey: id,
+	}).Decode(build)
+	if err != nil {
+		return nil, err
+	}
+	return build, nil
+}
+
+// FetchReleases is an abstraction over FetchDocs for retrieving the releases of an application
+// Latest releases are returned first
+func FetchReleases(filter types.M) []types.M {
+	return FetchDocs(ReleaseCollection, filter, options.Find().SetSort(types.M{TimestampKey: -1}))
+}
+
+// FetchSingleRelease returns a release of an application
+func FetchSingleRelease(name, id string) (*types.Release, error) {
+	collection := link.Collection(ReleaseCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	release := &types.Release{}
+	err := collection.FindOne(ctx, types.M{
+		NameKey:      name,
+		ReleaseIDKey: id,
+	}).Decode(release)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// FetchReplicas is an abstraction over FetchDocs for retrieving the replicas of applications
+// Oldest replicas are returned first
+func FetchReplicas(filter types.M) []types.M {
+	return FetchDocs(ReplicaCollection, filter, options.Find().SetSort(types.M{CreatedAtKey: 1}))
+}
+
+// FetchResources returns the resources requested by the documents of a collection matching the filter
+func FetchResources(collectionName string, filter types.M) ([]types.Resources, error) {
+	collection := link.Collection(collectionName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetProjection(types.M{ResourcesKey: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	resources := make([]types.Resources, 0)
+	for cur.Next(ctx) {
+		var result struct {
+			Resources types.Resources `bson:"resources"`
+		}
+		if err := cur.Decode(&result); err != nil {
+			return nil, err
+		}
+		resources = append(resources, result.Resources)
+	}
+	return resources, cur.Err()
+}
+
+// FetchNames returns the names of the documents of a collection matching the filter
+// Unlike FetchDocs, an error is returned if the documents cannot be fetched
+func FetchNames(collectionName string, filter types.M) ([]string, error) {
+	collection := link.Collection(collectionName)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetProjection(types.M{NameKey: 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	names := make([]string, 0)
+	for cur.Next(ctx) {
+		var result struct {
+			Name string `bson:"name"`
+		}
+		if err := cur.Decode(&result); err != nil {
+			return nil, err
+		}
+		names = append(names, result.Name)
+	}
+	return names, cur.Err()
+}
+
+// FetchNodeLabels returns the labels attached to nodes by admins mapped to the IP addresses of the nodes
+func FetchNodeLabels() (map[string]map[string]string, error) {
+	collection := link.Collection(NodeLabelsCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, types.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	nodeLabels := make(map[string]map[string]string)
+	for cur.Next(ctx) {
+		node := &types.NodeLabels{}
+		if err := cur.Decode(node); err != nil {
+			return nil, err
+		}
+		nodeLabels[node.HostIP] = node.Labels
+	}
+	return nodeLabels, cur.Err()
+}
+
+// FetchDeployKey returns the deploy key of an application
+func FetchDeployKey(name string) (*types.DeployKey, error) {
+	collection := link.Collection(DeployKeyCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := &types.DeployKey{}
+	err := collection.FindOne(ctx, types.M{NameKey: name}).Decode(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// FetchDomain returns a custom domain based on its hostname
+func FetchDomain(hostname string) (*types.Domain, error) {
+	collection := link.Collection(DomainCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	domain := &types.Domain{}
+	err := collection.FindOne(ctx, types.M{HostnameKey: hostname}).Decode(domain)
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// FetchDomains is an abstraction over FetchDocs for retrieving the custom domains of applications
+func FetchDomains(filter types.M) []types.M {
+	return FetchDocs(DomainCollection, filter)
+}
+
+// FetchRoutes returns the path based routes of applications
+func FetchRoutes(filter types.M) ([]*types.Route, error) {
+	collection := link.Collection(RouteCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	routes := make([]*types.Route, 0)
+	for cur.Next(ctx) {
+		route := &types.Route{}
+		if err := cur.Decode(route); err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, cur.Err()
+}
+
+// FetchCertificates returns the certificates of custom domains obtained over ACME
+func FetchCertificates(filter types.M) ([]*types.Certificate, error) {
+	collection := link.Collection(CertificateCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	certificates := make([]*types.Certificate, 0)
+	for cur.Next(ctx) {
+		certificate := &types.Certificate{}
+		if err := cur.Decode(certificate); err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, cur.Err()
+}
+
+// FetchWebhook returns the webhook of an application
+func FetchWebhook(name string) (*types.Webhook, error) {
+	collection := link.Collection(WebhookCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook := &types.Webhook{}
+	err := collection.FindOne(ctx, types.M{NameKey: name}).Decode(webhook)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// FetchWebhookDeliveries is an abstraction over FetchDocs for retrieving the webhook deliveries
+// of an application, latest deliveries are returned first
+func FetchWebhookDeliveries(filter types.M, count int64) []types.M {
+	options := options.Find().SetSort(types.M{TimestampKey: -1})
+	if count > 0 {
+		options.SetLimit(count)
+	}
+	return FetchDocs(WebhookDeliveryCollection, filter, options)
+}
+
 // CountDocs returns the number of documents matching a filter
 func CountDocs(collectionName string, filter types.M) (int64, error) {
 	collection := link.Collection(collectionName)
@@ -28,6 +28,102 @@ func UpsertInstance(filter types.M, data interface{}) error {
 	return UpdateOne(InstanceCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
 }
 
+// UpdateAppState is an abstraction over UpdateInstance which updates the lifecycle state of an application
+func UpdateAppState(name, state string) error {
+	return UpdateInstance(
+		types.M{
+			NameKey:         name,
+			InstanceTypeKey: AppInstance,
+		},
+		types.M{
+			StateKey: state,
+		},
+	)
+}
+
+// UpdateAppRebuildState is an abstraction over UpdateInstance which updates the lifecycle state
+// of the deployment built by the latest rebuild of an application
+func UpdateAppRebuildState(name, state string) error {
+	return UpdateInstance(
+		types.M{
+			NameKey:         name,
+			InstanceTypeKey: AppInstance,
+		},
+		types.M{
+			RebuildStateKey: state,
+		},
+	)
+}
+
+// UpsertReplica is an abstraction over UpdateOne which updates an application's replica
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertReplica(filter types.M, data interface{}) error {
+	return UpdateOne(ReplicaCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpdateReplicaState is an abstraction over UpdateOne which updates the lifecycle state
+// of an application's replica deployed on the given host
+func UpdateReplicaState(name, hostIP, state string) error {
+	return UpdateOne(
+		ReplicaCollection,
+		types.M{
+			NameKey:   name,
+			HostIPKey: hostIP,
+		},
+		types.M{
+			StateKey: state,
+		},
+		nil,
+	)
+}
+
+// UpsertNodeLabels is an abstraction over UpdateOne which updates the labels attached to a node
+// in mongoDB or inserts them if the corresponding document doesn't exist
+func UpsertNodeLabels(filter types.M, data interface{}) error {
+	return UpdateOne(NodeLabelsCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpdateBuild is an abstraction over UpdateOne which updates an application's build record in mongoDB
+func UpdateBuild(filter types.M, data interface{}) error {
+	return UpdateOne(BuildCollection, filter, data, nil)
+}
+
+// UpsertWebhook is an abstraction over UpdateOne which updates an application's webhook
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertWebhook(filter types.M, data interface{}) error {
+	return UpdateOne(WebhookCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpsertDeployKey is an abstraction over UpdateOne which updates an application's deploy key
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertDeployKey(filter types.M, data interface{}) error {
+	return UpdateOne(DeployKeyCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpsertDomain is an abstraction over UpdateOne which updates an application's custom domain
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertDomain(filter types.M, data interface{}) error {
+	return UpdateOne(DomainCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpsertRoute is an abstraction over UpdateOne which updates a path based route of an application
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertRoute(filter types.M, data interface{}) error {
+	return UpdateOne(RouteCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpsertCertificate is an abstraction over UpdateOne which updates the certificate of a custom domain
+// in mongoDB or inserts it if the corresponding document doesn't exist
+func UpsertCertificate(filter types.M, data interface{}) error {
+	return UpdateOne(CertificateCollection, filter, data, options.FindOneAndUpdate().SetUpsert(true))
+}
+
+// UpdateWebhookDelivery is an abstraction over UpdateOne which updates an application's
+// webhook delivery record in mongoDB
+func UpdateWebhookDelivery(filter types.M, data interface{}) error {
+	return UpdateOne(WebhookDeliveryCollection, filter, data, nil)
+}
+
 // UpdateUser is an abstraction over UpdateOne which updates an application in mongoDB
 func UpdateUser(filter types.M, data interface{}) error {
 	return UpdateOne(UserCollection, filter, data, nil)
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// tokenBucketScript takes a token from the bucket of a client IP after refilling it for the time elapsed
+// since it was last used, the bucket expires once it would have been refilled completely
+// It returns 1 if a token was taken and 0 otherwise
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'timestamp')
+local tokens = tonumber(bucket[1])
+local timestamp = tonumber(bucket[2])
+if tokens == nil or timestamp == nil then
+	tokens = capacity
+	timestamp = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - timestamp) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'timestamp', math.max(now, timestamp))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate))
+return allowed
+`)
+
+// accessRejectionField returns the field holding the number of requests to an application rejected for a reason
+func accessRejectionField(appName, reason string) string {
+	return fmt.Sprintf("%s:%s", appName, reason)
+}
+
+// RegisterAccessPolicy registers the access policy of an application in the access policies HashMap
+func RegisterAccessPolicy(appName string, policy *types.AccessPolicy) error {
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	_, err = client.HSet(AccessPolicyKey, appName, policyJSON).Result()
+	return err
+}
+
+// FetchAccessPolicies returns the access policies of all applications having one mapped to their names
+func FetchAccessPolicies() (map[string]*types.AccessPolicy, error) {
+	data, err := client.HGetAll(AccessPolicyKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]*types.AccessPolicy)
+	for appName, policyJSON := range data {
+		policy := &types.AccessPolicy{}
+		if err := json.Unmarshal([]byte(policyJSON), policy); err != nil {
+			return nil, err
+		}
+		policies[appName] = policy
+	}
+	return policies, nil
+}
+
+// RemoveAccessPolicy removes the access policy of an application
+func RemoveAccessPolicy(appName string) error {
+	_, err := client.HDel(AccessPolicyKey, appName).Result()
+	return err
+}
+
+// TakeRateLimitToken takes a token from the bucket of a client IP for an application and returns
+// whether the request is within the application's rate limit
+// The buckets are shared by all GenProxy instances
+func TakeRateLimitToken(appName, clientIP string, limit *types.RateLimit) (bool, error) {
+	key := fmt.Sprintf("%s%s:%s", RateLimitKeyPrefix, appName, clientIP)
+	// The rate is passed as tokens per millisecond since timestamps are in milliseconds
+	rate := limit.Rate() / 1000
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	allowed, err := tokenBucketScript.Run(client, []string{key}, limit.Capacity(), rate, now).Int()
+	if err != nil {
+		return false, err
+	}
+	return allowed == 1, nil
+}
+
+// RecordAccessRejections adds the numbers of rejected requests to applications mapped to the applications
+// and the reasons of rejection
+func RecordAccessRejections(rejections map[string]map[string]int64) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for appName, reasons := range rejections {
+			for reason, count := range reasons {
+				pipe.HIncrBy(AccessRejectionKey, accessRejectionField(appName, reason), count)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// FetchAccessRejections returns the numbers of requests to an application rejected by its access policy
+// mapped to the reasons of rejection
+func FetchAccessRejections(appName string) (map[string]int64, error) {
+	reasons := []string{types.AccessDenied, types.AccessRateLimited}
+	fields := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		fields = append(fields, accessRejectionField(appName, reason))
+	}
+	values, err := client.HMGet(AccessRejectionKey, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	rejections := make(map[string]int64, len(reasons))
+	for i, reason := range reasons {
+		rejections[reason] = 0
+		if value, ok := values[i].(string); ok {
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+				rejections[reason] = count
+			}
+		}
+	}
+	return rejections, nil
+}
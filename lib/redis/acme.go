@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// RegisterACMEAccount stores the encrypted private key of the ACME account unless one has already been stored
+// The stored private key is returned so that all GenProxy instances share the same account
+func RegisterACMEAccount(privateKey string) (string, error) {
+	if _, err := client.SetNX(ACMEAccountKey, privateKey, 0).Result(); err != nil {
+		return "", err
+	}
+	return client.Get(ACMEAccountKey).Result()
+}
+
+// FetchACMEAccount returns the encrypted private key of the ACME account, an empty string is returned if there is none
+func FetchACMEAccount() (string, error) {
+	privateKey, err := client.Get(ACMEAccountKey).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return privateKey, err
+}
+
+// RegisterACMEChallenge registers the key authorization answering a pending ACME HTTP-01 challenge
+func RegisterACMEChallenge(token, keyAuth string) error {
+	_, err := client.HSet(ACMEChallengeKey, token, keyAuth).Result()
+	return err
+}
+
+// FetchACMEChallenge returns the key authorization answering a pending ACME HTTP-01 challenge
+func FetchACMEChallenge(token string) (string, error) {
+	return client.HGet(ACMEChallengeKey, token).Result()
+}
+
+// RemoveACMEChallenge removes a completed ACME HTTP-01 challenge
+func RemoveACMEChallenge(token string) error {
+	_, err := client.HDel(ACMEChallengeKey, token).Result()
+	return err
+}
+
+// AcquireCertificateLock locks the issuance of a hostname's certificate to the given holder
+// The lock expires after the given duration so that a crashed holder doesn't block the issuance
+func AcquireCertificateLock(hostname, holder string, ttl time.Duration) (bool, error) {
+	return client.SetNX(CertificateLockKeyPrefix+hostname, holder, ttl).Result()
+}
+
+// ReleaseCertificateLock releases the lock on the issuance of a hostname's certificate
+func ReleaseCertificateLock(hostname string) error {
+	_, err := client.Del(CertificateLockKeyPrefix + hostname).Result()
+	return err
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// recordActivityScript stores the given times of the latest requests to applications unless
+// a later request to the same application has already been stored
+var recordActivityScript = redis.NewScript(`
+for i = 1, #ARGV, 2 do
+	local current = tonumber(redis.call('HGET', KEYS[1], ARGV[i]))
+	local at = tonumber(ARGV[i + 1])
+	if not current or at > current then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+	end
+end
+return 1
+`)
+
+// RecordAppActivity stores the time (unix seconds) of the latest request to applications in the app activity HashMap
+// The activity is recorded by several GenProxy instances hence an older time never replaces a newer one
+func RecordAppActivity(activity map[string]int64) error {
+	if len(activity) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, 2*len(activity))
+	for appName, at := range activity {
+		args = append(args, appName, at)
+	}
+	return recordActivityScript.Run(client, []string{AppActivityKey}, args...).Err()
+}
+
+// InitAppActivity stores the given time as the latest request to an application
+// only if no request to the application has been recorded yet
+func InitAppActivity(appName string, at time.Time) error {
+	_, err := client.HSetNX(AppActivityKey, appName, at.Unix()).Result()
+	return err
+}
+
+// FetchAppActivity returns the time (unix seconds) of the latest request to applications mapped to their names
+func FetchAppActivity() (map[string]int64, error) {
+	data, err := client.HGetAll(AppActivityKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	activity := make(map[string]int64, len(data))
+	for appName, value := range data {
+		at, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		activity[appName] = at
+	}
+	return activity, nil
+}
@@ -1,32 +1,103 @@
 package redis
 
 import (
-	"encoding/json"
+	"fmt"
 
+	"github.com/go-redis/redis"
 	"github.com/sdslabs/gasper/types"
 )
 
 // RegisterApp registers the app in the applications HashMap with its server and node url
+// The bindings of the application's replicas (if any) are retained and the app is marked as serving requests
 func RegisterApp(appName, nodeURL, serverURL string) error {
-	appBind := &types.InstanceBindings{
-		Node:   nodeURL,
-		Server: serverURL,
-	}
-	appBindingJSON, err := json.Marshal(appBind)
-	if err != nil {
-		return err
-	}
-	_, err = client.HSet(ApplicationKey, appName, appBindingJSON).Result()
-	return err
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		appBind.Node = nodeURL
+		appBind.Server = serverURL
+		appBind.Stopped = false
+		appBind.Sleeping = false
+		return nil
+	})
 }
 
-// BulkRegisterApps registers multiple apps at once
-func BulkRegisterApps(data types.M) error {
-	if len(data) == 0 {
+// RelocateApp updates the server and node url of the app which has been deployed on another node
+// The app stays stopped or sleeping if it was marked so
+func RelocateApp(appName, nodeURL, serverURL string) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		appBind.Node = nodeURL
+		appBind.Server = serverURL
 		return nil
-	}
-	_, err := client.HMSet(ApplicationKey, data).Result()
-	return err
+	})
+}
+
+// MarkAppStopped marks whether the app has been stopped by its owner
+// The app is no longer sleeping in either case
+func MarkAppStopped(appName string, stopped bool) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		if appBind.Node == "" {
+			return fmt.Errorf("Application %s is not registered", appName)
+		}
+		appBind.Stopped = stopped
+		appBind.Sleeping = false
+		return nil
+	})
+}
+
+// MarkAppSleeping marks whether the app has been put to sleep for receiving no requests
+func MarkAppSleeping(appName string, sleeping bool) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		if appBind.Node == "" {
+			return fmt.Errorf("Application %s is not registered", appName)
+		}
+		appBind.Sleeping = sleeping
+		return nil
+	})
+}
+
+// RegisterAppReplica registers a replica of the app running on the node with the given server url
+// The previous replica of the app on the same node (if any) is replaced
+func RegisterAppReplica(appName, nodeURL, serverURL string) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		if appBind.Node == "" {
+			return fmt.Errorf("Application %s is not registered", appName)
+		}
+		replicas := []types.InstanceBindings{{
+			Node:   nodeURL,
+			Server: serverURL,
+		}}
+		for _, replica := range appBind.Replicas {
+			if replica.Node != nodeURL {
+				replicas = append(replicas, replica)
+			}
+		}
+		appBind.Replicas = replicas
+		return nil
+	})
+}
+
+// RemoveAppReplica removes the replica of the app running on the given node
+func RemoveAppReplica(appName, nodeURL string) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		if appBind.Node == "" {
+			return fmt.Errorf("Application %s is not registered", appName)
+		}
+		replicas := make([]types.InstanceBindings, 0)
+		for _, replica := range appBind.Replicas {
+			if replica.Node != nodeURL {
+				replicas = append(replicas, replica)
+			}
+		}
+		appBind.Replicas = replicas
+		return nil
+	})
+}
+
+// RefreshApp refreshes the bindings of the app with the ones exposed by its node
+// The app stays stopped or sleeping if it was marked so
+func RefreshApp(appName string, latest *types.InstanceBindings) error {
+	return updateAppBindings(appName, func(appBind *types.InstanceBindings) error {
+		appBind.Refresh(latest)
+		return nil
+	})
 }
 
 // FetchAppServer returns the URL of deployed application
@@ -34,17 +105,35 @@ func FetchAppServer(appName string) (string, error) {
 	return fetchServer(ApplicationKey, appName)
 }
 
+// FetchAppBindings returns the server and node urls of the application along with those of its replicas
+func FetchAppBindings(appName string) (*types.InstanceBindings, error) {
+	return fetchBindings(ApplicationKey, appName)
+}
+
 // FetchAppNode returns the URL of the node where the application is deployed
 func FetchAppNode(appName string) (string, error) {
 	return fetchNode(ApplicationKey, appName)
 }
 
-// RemoveApp removes the application's entry from Redis
+// RemoveApp removes the application's entry from Redis along with its latest activity and the policies
+// enforced by GenProxy
 func RemoveApp(appName string) error {
-	_, err := client.HDel(ApplicationKey, appName).Result()
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HDel(ApplicationKey, appName)
+		pipe.HDel(AppActivityKey, appName)
+		pipe.HDel(HTTPSPolicyKey, appName)
+		pipe.HDel(AccessPolicyKey, appName)
+		pipe.HDel(AccessRejectionKey, accessRejectionField(appName, types.AccessDenied), accessRejectionField(appName, types.AccessRateLimited))
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	publishInstanceEvent(&types.InstanceEvent{
+		Key:     ApplicationKey,
+		Name:    appName,
+		Removed: true,
+	})
 	return nil
 }
 
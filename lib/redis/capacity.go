@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// reserveCapacityScript reserves resources on a worker node for an instance if they still fit in the
+// resources which can be allocated on it and records the reservation till the instance is stored
+// It returns 1 if the resources were reserved and 0 otherwise
+var reserveCapacityScript = redis.NewScript(`
+local data = redis.call('HGET', KEYS[1], ARGV[1])
+if not data then
+	return 0
+end
+local capacity = cjson.decode(data)
+local cpu = tonumber(ARGV[2])
+local memory = tonumber(ARGV[3])
+if cpu > capacity.allocatable_cpu or memory > capacity.allocatable_memory then
+	return 0
+end
+capacity.allocatable_cpu = capacity.allocatable_cpu - cpu
+capacity.allocatable_memory = capacity.allocatable_memory - memory
+redis.call('HSET', KEYS[1], ARGV[1], cjson.encode(capacity))
+redis.call('HSET', KEYS[2], ARGV[4], ARGV[5])
+return 1
+`)
+
+// registerCapacityScript registers the capacity reported by a worker node after deducting the resources
+// reserved for instances which are not stored yet, the reservations of stored instances and the expired
+// ones are dropped
+var registerCapacityScript = redis.NewScript(`
+local capacity = cjson.decode(ARGV[2])
+local now = tonumber(ARGV[3])
+local stored = {}
+for i = 4, #ARGV do
+	stored[ARGV[i]] = true
+end
+local reservations = redis.call('HGETALL', KEYS[2])
+for i = 1, #reservations, 2 do
+	local name = reservations[i]
+	local reservation = cjson.decode(reservations[i + 1])
+	if stored[name] or reservation.expires_at <= now then
+		redis.call('HDEL', KEYS[2], name)
+	else
+		capacity.allocatable_cpu = capacity.allocatable_cpu - reservation.cpu
+		capacity.allocatable_memory = capacity.allocatable_memory - reservation.memory
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], cjson.encode(capacity))
+return 1
+`)
+
+// RegisterNodeCapacity registers the resource capacity of a worker node in the node capacities HashMap
+// Resources stay reserved for the instances placed on the node until they are among the stored instances
+func RegisterNodeCapacity(nodeURL string, capacity *types.NodeCapacity, stored []string) error {
+	capacityJSON, err := json.Marshal(capacity)
+	if err != nil {
+		return err
+	}
+	args := []interface{}{nodeURL, capacityJSON, time.Now().Unix()}
+	for _, name := range stored {
+		args = append(args, name)
+	}
+	return registerCapacityScript.Run(client, []string{NodeCapacityKey, NodeReservationsKeyPrefix + nodeURL}, args...).Err()
+}
+
+// FetchNodeCapacities returns the resource capacities of all worker nodes mapped to their URLs
+func FetchNodeCapacities() (map[string]*types.NodeCapacity, error) {
+	data, err := client.HGetAll(NodeCapacityKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	capacities := make(map[string]*types.NodeCapacity)
+	for nodeURL, capacityJSON := range data {
+		capacity := &types.NodeCapacity{}
+		if err := json.Unmarshal([]byte(capacityJSON), capacity); err != nil {
+			return nil, err
+		}
+		capacities[nodeURL] = capacity
+	}
+	return capacities, nil
+}
+
+// ReserveNodeCapacity atomically reserves the requested resources on a worker node for an instance and
+// returns whether they could be reserved, which is not the case if they no longer fit on the node
+// The reservation lasts until the node reports the instance as stored or the reservation expires
+func ReserveNodeCapacity(nodeURL, name string, resources types.Resources, ttl time.Duration) (bool, error) {
+	reservationJSON, err := json.Marshal(&types.CapacityReservation{
+		CPU:       resources.GetCPU(),
+		Memory:    resources.GetMemory(),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+	reserved, err := reserveCapacityScript.Run(client, []string{NodeCapacityKey, NodeReservationsKeyPrefix + nodeURL},
+		nodeURL, resources.GetCPU(), resources.GetMemory(), name, reservationJSON).Int()
+	if err != nil {
+		return false, err
+	}
+	return reserved == 1, nil
+}
+
+// RemoveNodeCapacity removes the resource capacity of a worker node from Redis along with the
+// resources reserved on it
+func RemoveNodeCapacity(nodeURL string) error {
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HDel(NodeCapacityKey, nodeURL)
+		pipe.Del(NodeReservationsKeyPrefix + nodeURL)
+		return nil
+	})
+	return err
+}
@@ -9,6 +9,77 @@ const (
 	// DatabaseKey is the key name for the HashMap containing database instances
 	DatabaseKey string = "databases"
 
+	// InstanceEventChannel is the channel on which the changes to the bindings of application and
+	// database instances are published
+	InstanceEventChannel string = "instance_events"
+
+	// NodeCapacityKey is the key name for the HashMap containing the resource capacities of worker nodes
+	NodeCapacityKey string = "node_capacities"
+
+	// NodeReservationsKeyPrefix is the prefix of the key names for the HashMaps containing the resources
+	// reserved on worker nodes mapped to the names of the instances they are reserved for
+	NodeReservationsKeyPrefix string = "node_reservations:"
+
+	// NodeLabelsKey is the key name for the HashMap containing the labels of nodes defined in their configuration
+	NodeLabelsKey string = "node_labels"
+
+	// CordonedNodesKey is the key name for the Set containing the IP addresses of cordoned nodes
+	CordonedNodesKey string = "cordoned_nodes"
+
+	// NodeDrainsKey is the key name for the HashMap containing the progress of draining nodes
+	NodeDrainsKey string = "node_drains"
+
+	// RescheduleQueueKey is the key name for the Sorted Set containing the names of applications waiting
+	// to be rescheduled scored by the time of their next attempt
+	RescheduleQueueKey string = "reschedule_queue"
+
+	// RescheduleJobsKey is the key name for the HashMap containing the jobs for rescheduling applications
+	RescheduleJobsKey string = "reschedule_jobs"
+
+	// DomainKey is the key name for the HashMap containing the applications served on verified custom domains
+	DomainKey string = "domains"
+
+	// DomainChallengeKey is the key name for the HashMap containing the HTTP challenge tokens of unverified custom domains
+	DomainChallengeKey string = "domain_challenges"
+
+	// RouteKey is the key name for the HashMap containing the path based routes of applications
+	RouteKey string = "routes"
+
+	// HTTPSPolicyKey is the key name for the HashMap containing the HTTPS policies of applications
+	HTTPSPolicyKey string = "https_policies"
+
+	// AccessPolicyKey is the key name for the HashMap containing the access policies of applications
+	AccessPolicyKey string = "access_policies"
+
+	// AccessRejectionKey is the key name for the HashMap containing the number of requests to applications
+	// rejected by their access policies
+	AccessRejectionKey string = "access_rejections"
+
+	// RateLimitKeyPrefix is the prefix of the key names for the HashMaps holding the token buckets of client IPs
+	RateLimitKeyPrefix string = "rate_limit:"
+
+	// ACMEAccountKey is the key name for the String holding the encrypted private key of GenProxy's ACME account
+	ACMEAccountKey string = "acme_account"
+
+	// ACMEChallengeKey is the key name for the HashMap containing the key authorizations of pending
+	// ACME HTTP-01 challenges mapped to their tokens
+	ACMEChallengeKey string = "acme_challenges"
+
+	// CertificateLockKeyPrefix is the prefix of the key names for the Strings locking the issuance of certificates
+	CertificateLockKeyPrefix string = "certificate_lock:"
+
+	// AppActivityKey is the key name for the HashMap containing the time of the latest request to applications
+	AppActivityKey string = "app_activity"
+
+	// LeaderKey is the key name for the String holding the Master instance which is the leader
+	LeaderKey string = "master_leader"
+
+	// HeartbeatKeyPrefix is the prefix of the key names for the Strings holding the unexpired heartbeats of nodes
+	HeartbeatKeyPrefix string = "heartbeat:"
+
+	// NodeHeartbeatsKey is the key name for the HashMap containing the last heartbeats of nodes
+	NodeHeartbeatsKey string = "node_heartbeats"
+
 	// SSHKey is the key name for the Sorted Set containing ssh microservice instances
 	SSHKey string = types.GenSSH
 
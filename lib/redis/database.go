@@ -17,7 +17,15 @@ func RegisterDB(dbName, nodeURL, serverURL string) error {
 		return err
 	}
 	_, err = client.HSet(DatabaseKey, dbName, dbBindingJSON).Result()
-	return err
+	if err != nil {
+		return err
+	}
+	publishInstanceEvent(&types.InstanceEvent{
+		Key:      DatabaseKey,
+		Name:     dbName,
+		Bindings: dbBind,
+	})
+	return nil
 }
 
 // FetchDbServer returns the URL of the database's server
@@ -36,6 +44,11 @@ func RemoveDB(dbName string) error {
 	if err != nil {
 		return err
 	}
+	publishInstanceEvent(&types.InstanceEvent{
+		Key:     DatabaseKey,
+		Name:    dbName,
+		Removed: true,
+	})
 	return nil
 }
 
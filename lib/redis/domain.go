@@ -0,0 +1,39 @@
+package redis
+
+import "github.com/go-redis/redis"
+
+// RegisterDomain registers a verified custom domain on which an application is served
+func RegisterDomain(hostname, appName string) error {
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HSet(DomainKey, hostname, appName)
+		pipe.HDel(DomainChallengeKey, hostname)
+		return nil
+	})
+	return err
+}
+
+// RegisterDomainChallenge registers the token which answers the HTTP challenge of an unverified custom domain
+func RegisterDomainChallenge(hostname, token string) error {
+	_, err := client.HSet(DomainChallengeKey, hostname, token).Result()
+	return err
+}
+
+// FetchDomainChallenge returns the token which answers the HTTP challenge of an unverified custom domain
+func FetchDomainChallenge(hostname string) (string, error) {
+	return client.HGet(DomainChallengeKey, hostname).Result()
+}
+
+// FetchAllDomains returns all verified custom domains along with the applications served on them
+func FetchAllDomains() (map[string]string, error) {
+	return client.HGetAll(DomainKey).Result()
+}
+
+// RemoveDomain removes a custom domain along with its HTTP challenge
+func RemoveDomain(hostname string) error {
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HDel(DomainKey, hostname)
+		pipe.HDel(DomainChallengeKey, hostname)
+		return nil
+	})
+	return err
+}
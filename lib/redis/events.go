@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// publishInstanceEvent publishes a change to the bindings of an instance on the instance events channel
+// Failures are only logged as the subscribers periodically resync with the HashMaps holding the bindings
+func publishInstanceEvent(event *types.InstanceEvent) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		utils.LogError("Redis-Events-1", err)
+		return
+	}
+	if _, err := client.Publish(InstanceEventChannel, eventJSON).Result(); err != nil {
+		utils.LogError("Redis-Events-2", err)
+	}
+}
+
+// SubscribeInstanceEvents calls the handler for every change to the bindings of application and database instances
+// It blocks the caller, the subscription is restored by the client if the connection to Redis is lost
+// and the events published meanwhile are missed
+func SubscribeInstanceEvents(handler func(*types.InstanceEvent)) {
+	pubsub := client.Subscribe(InstanceEventChannel)
+	defer pubsub.Close()
+	for message := range pubsub.Channel() {
+		event := &types.InstanceEvent{}
+		if err := json.Unmarshal([]byte(message.Payload), event); err != nil {
+			utils.LogError("Redis-Events-3", err)
+			continue
+		}
+		handler(event)
+	}
+}
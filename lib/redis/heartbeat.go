@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// RegisterHeartbeat registers the heartbeat of a node which expires after the given duration
+// The heartbeat is also kept in the node heartbeats HashMap so that the last heartbeat of a dead node can be inspected
+func RegisterHeartbeat(heartbeat *types.Heartbeat, ttl time.Duration) error {
+	heartbeatJSON, err := json.Marshal(heartbeat)
+	if err != nil {
+		return err
+	}
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(HeartbeatKeyPrefix+heartbeat.HostIP, heartbeatJSON, ttl)
+		pipe.HSet(NodeHeartbeatsKey, heartbeat.HostIP, heartbeatJSON)
+		return nil
+	})
+	return err
+}
+
+// FetchHeartbeat returns the unexpired heartbeat of a node, nil is returned if the heartbeat has expired
+func FetchHeartbeat(hostIP string) (*types.Heartbeat, error) {
+	heartbeatJSON, err := client.Get(HeartbeatKeyPrefix + hostIP).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	heartbeat := &types.Heartbeat{}
+	if err := json.Unmarshal([]byte(heartbeatJSON), heartbeat); err != nil {
+		return nil, err
+	}
+	heartbeat.Alive = true
+	return heartbeat, nil
+}
+
+// FetchNodeHeartbeats returns the last heartbeats of all nodes mapped to their IP addresses
+// along with whether they have expired or not
+func FetchNodeHeartbeats() (map[string]*types.Heartbeat, error) {
+	data, err := client.HGetAll(NodeHeartbeatsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	heartbeats := make(map[string]*types.Heartbeat)
+	for hostIP, heartbeatJSON := range data {
+		heartbeat := &types.Heartbeat{}
+		if err := json.Unmarshal([]byte(heartbeatJSON), heartbeat); err != nil {
+			return nil, err
+		}
+		heartbeat.Alive = heartbeat.ExpiresAt > now
+		heartbeats[hostIP] = heartbeat
+	}
+	return heartbeats, nil
+}
@@ -2,10 +2,28 @@ package redis
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/go-redis/redis"
 	"github.com/sdslabs/gasper/types"
 )
 
+// maxUpdateRetries is the number of times an update of the bindings of an instance is retried
+// when they are modified concurrently
+const maxUpdateRetries = 5
+
+// compareAndSetScript sets a field of a HashMap only if the field still holds the expected value,
+// an empty expected value denotes that the field must not exist
+// It returns 1 if the field was set and 0 otherwise
+var compareAndSetScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], ARGV[1])
+if (current or '') ~= ARGV[2] then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+return 1
+`)
+
 // fetchBindings returns a struct containing an instance's server and node URL
 func fetchBindings(key, name string) (*types.InstanceBindings, error) {
 	result, err := client.HGet(key, name).Result()
@@ -38,3 +56,45 @@ func fetchNode(key, name string) (string, error) {
 	}
 	return instance.Node, nil
 }
+
+// updateAppBindings applies the update to the bindings of an application atomically
+// The update is retried if the bindings of the application are modified concurrently
+// and the updated bindings are published once they have been stored, bindings which
+// the update leaves unchanged are neither stored nor published
+func updateAppBindings(appName string, update func(*types.InstanceBindings) error) error {
+	for i := 0; i < maxUpdateRetries; i++ {
+		current, err := client.HGet(ApplicationKey, appName).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		appBind := &types.InstanceBindings{}
+		if err == nil {
+			if err := json.Unmarshal([]byte(current), appBind); err != nil {
+				return err
+			}
+		}
+		if err := update(appBind); err != nil {
+			return err
+		}
+		appBindingJSON, err := json.Marshal(appBind)
+		if err != nil {
+			return err
+		}
+		if string(appBindingJSON) == current {
+			return nil
+		}
+		updated, err := compareAndSetScript.Run(client, []string{ApplicationKey}, appName, current, appBindingJSON).Int()
+		if err != nil {
+			return err
+		}
+		if updated == 1 {
+			publishInstanceEvent(&types.InstanceEvent{
+				Key:      ApplicationKey,
+				Name:     appName,
+				Bindings: appBind,
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("Bindings of application %s were modified concurrently", appName)
+}
@@ -0,0 +1,40 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/sdslabs/gasper/types"
+)
+
+// RegisterHTTPSPolicy registers the HTTPS policy of an application in the HTTPS policies HashMap
+func RegisterHTTPSPolicy(appName string, policy *types.HTTPSPolicy) error {
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	_, err = client.HSet(HTTPSPolicyKey, appName, policyJSON).Result()
+	return err
+}
+
+// FetchHTTPSPolicies returns the HTTPS policies of all applications having one mapped to their names
+func FetchHTTPSPolicies() (map[string]*types.HTTPSPolicy, error) {
+	data, err := client.HGetAll(HTTPSPolicyKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]*types.HTTPSPolicy)
+	for appName, policyJSON := range data {
+		policy := &types.HTTPSPolicy{}
+		if err := json.Unmarshal([]byte(policyJSON), policy); err != nil {
+			return nil, err
+		}
+		policies[appName] = policy
+	}
+	return policies, nil
+}
+
+// RemoveHTTPSPolicy removes the HTTPS policy of an application so that the default policy applies to it
+func RemoveHTTPSPolicy(appName string) error {
+	_, err := client.HDel(HTTPSPolicyKey, appName).Result()
+	return err
+}
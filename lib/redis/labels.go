@@ -0,0 +1,30 @@
+package redis
+
+import "encoding/json"
+
+// RegisterNodeLabels registers the labels of a node in the node labels HashMap
+func RegisterNodeLabels(hostIP string, labels map[string]string) error {
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	_, err = client.HSet(NodeLabelsKey, hostIP, labelsJSON).Result()
+	return err
+}
+
+// FetchNodeLabels returns the labels of all nodes mapped to their IP addresses
+func FetchNodeLabels() (map[string]map[string]string, error) {
+	data, err := client.HGetAll(NodeLabelsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	nodeLabels := make(map[string]map[string]string)
+	for hostIP, labelsJSON := range data {
+		labels := make(map[string]string)
+		if err := json.Unmarshal([]byte(labelsJSON), &labels); err != nil {
+			return nil, err
+		}
+		nodeLabels[hostIP] = labels
+	}
+	return nodeLabels, nil
+}
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// AcquireLeadership makes the candidate the leader if there is no leader or renews the candidate's lease
+// if it already is the leader, the leadership is lost if the lease isn't renewed before it expires
+func AcquireLeadership(candidate string, lease time.Duration) (bool, error) {
+	acquired, err := client.SetNX(LeaderKey, candidate, lease).Result()
+	if err != nil || acquired {
+		return acquired, err
+	}
+
+	renewed := false
+	transaction := func(tx *redis.Tx) error {
+		renewed = false
+		leader, err := tx.Get(LeaderKey).Result()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil || leader != candidate {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Set(LeaderKey, candidate, lease)
+			return nil
+		})
+		renewed = err == nil
+		return err
+	}
+	err = client.Watch(transaction, LeaderKey)
+	if err == redis.TxFailedErr {
+		// The lease expired or was taken over by another candidate during the renewal
+		return false, nil
+	}
+	return renewed, err
+}
+
+// FetchLeader returns the current leader, an empty string is returned if there is none
+func FetchLeader() (string, error) {
+	leader, err := client.Get(LeaderKey).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return leader, err
+}
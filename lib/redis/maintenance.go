@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/sdslabs/gasper/types"
+)
+
+// CordonNode marks a node as unschedulable so that no new instances are placed on it
+func CordonNode(hostIP string) error {
+	_, err := client.SAdd(CordonedNodesKey, hostIP).Result()
+	return err
+}
+
+// UncordonNode returns a node to the pool of nodes on which instances are placed
+func UncordonNode(hostIP string) error {
+	_, err := client.SRem(CordonedNodesKey, hostIP).Result()
+	return err
+}
+
+// FetchCordonedNodes returns the IP addresses of all cordoned nodes
+func FetchCordonedNodes() ([]string, error) {
+	return client.SMembers(CordonedNodesKey).Result()
+}
+
+// RegisterNodeDrain registers the progress of draining a node in the node drains HashMap
+func RegisterNodeDrain(drain *types.Drain) error {
+	drainJSON, err := json.Marshal(drain)
+	if err != nil {
+		return err
+	}
+	_, err = client.HSet(NodeDrainsKey, drain.HostIP, drainJSON).Result()
+	return err
+}
+
+// FetchNodeDrains returns the progress of draining nodes mapped to their IP addresses
+func FetchNodeDrains() (map[string]*types.Drain, error) {
+	data, err := client.HGetAll(NodeDrainsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	drains := make(map[string]*types.Drain)
+	for hostIP, drainJSON := range data {
+		drain := &types.Drain{}
+		if err := json.Unmarshal([]byte(drainJSON), drain); err != nil {
+			return nil, err
+		}
+		drains[hostIP] = drain
+	}
+	return drains, nil
+}
+
+// RemoveNodeDrain removes the progress of draining a node from Redis
+func RemoveNodeDrain(hostIP string) error {
+	_, err := client.HDel(NodeDrainsKey, hostIP).Result()
+	return err
+}
@@ -0,0 +1,170 @@
+package redis
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// EnqueueRescheduleJob adds a job for rescheduling an application to the rescheduling queue
+// The job is not added if the application already has a job, so that its attempts are kept
+func EnqueueRescheduleJob(job *types.RescheduleJob) error {
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	transaction := func(tx *redis.Tx) error {
+		exists, err := tx.HExists(RescheduleJobsKey, job.Name).Result()
+		if err != nil || exists {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.HSet(RescheduleJobsKey, job.Name, jobJSON)
+			pipe.ZAdd(RescheduleQueueKey, redis.Z{
+				Score:  float64(job.NextAttemptAt),
+				Member: job.Name,
+			})
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxUpdateRetries; i++ {
+		err = client.Watch(transaction, RescheduleJobsKey)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return err
+}
+
+// ClaimRescheduleJob claims the rescheduling job whose next attempt is the most overdue
+// The job is hidden from other claimers for the lease duration so that it is attempted again
+// if its claimer goes away, nil is returned if no job is due
+func ClaimRescheduleJob(lease time.Duration) (*types.RescheduleJob, error) {
+	var job *types.RescheduleJob
+	transaction := func(tx *redis.Tx) error {
+		job = nil
+		now := time.Now()
+		names, err := tx.ZRangeByScore(RescheduleQueueKey, redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(now.Unix(), 10),
+			Count: 1,
+		}).Result()
+		if err != nil || len(names) == 0 {
+			return err
+		}
+		name := names[0]
+		jobJSON, err := tx.HGet(RescheduleJobsKey, name).Result()
+		if err == redis.Nil {
+			// The job has been removed, hence its leftover entry in the queue is dropped
+			_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+				pipe.ZRem(RescheduleQueueKey, name)
+				return nil
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		claimed := &types.RescheduleJob{}
+		if err := json.Unmarshal([]byte(jobJSON), claimed); err != nil {
+			return err
+		}
+		claimed.State = types.RescheduleRunning
+		claimedJSON, err := json.Marshal(claimed)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.HSet(RescheduleJobsKey, name, claimedJSON)
+			pipe.ZAdd(RescheduleQueueKey, redis.Z{
+				Score:  float64(now.Add(lease).Unix()),
+				Member: name,
+			})
+			return nil
+		})
+		if err == nil {
+			job = claimed
+		}
+		return err
+	}
+
+	var err error
+	for i := 0; i < maxUpdateRetries; i++ {
+		err = client.Watch(transaction, RescheduleQueueKey, RescheduleJobsKey)
+		if err != redis.TxFailedErr {
+			return job, err
+		}
+	}
+	// The queue is contended by other claimers which are already working through it
+	return nil, nil
+}
+
+// RegisterRescheduleJob updates a rescheduling job, the job is queued for its next attempt
+// unless it is stuck in which case it is only kept for admins to inspect
+func RegisterRescheduleJob(job *types.RescheduleJob) error {
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HSet(RescheduleJobsKey, job.Name, jobJSON)
+		if job.State == types.RescheduleStuck {
+			pipe.ZRem(RescheduleQueueKey, job.Name)
+		} else {
+			pipe.ZAdd(RescheduleQueueKey, redis.Z{
+				Score:  float64(job.NextAttemptAt),
+				Member: job.Name,
+			})
+		}
+		return nil
+	})
+	return err
+}
+
+// FetchRescheduleJob returns the rescheduling job of an application, nil is returned if there is none
+func FetchRescheduleJob(appName string) (*types.RescheduleJob, error) {
+	jobJSON, err := client.HGet(RescheduleJobsKey, appName).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	job := &types.RescheduleJob{}
+	if err := json.Unmarshal([]byte(jobJSON), job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// FetchRescheduleJobs returns all rescheduling jobs
+func FetchRescheduleJobs() ([]*types.RescheduleJob, error) {
+	data, err := client.HGetAll(RescheduleJobsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*types.RescheduleJob, 0, len(data))
+	for _, jobJSON := range data {
+		job := &types.RescheduleJob{}
+		if err := json.Unmarshal([]byte(jobJSON), job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// RemoveRescheduleJob removes the rescheduling job of an application
+func RemoveRescheduleJob(appName string) error {
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.ZRem(RescheduleQueueKey, appName)
+		pipe.HDel(RescheduleJobsKey, appName)
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/go-redis/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// routeField returns the field of a route in the routes HashMap
+// Paths always start with a slash hence the field is unique for every hostname and path
+func routeField(hostname, path string) string {
+	return hostname + path
+}
+
+// RegisterRoute registers a path based route of an application in the routes HashMap
+func RegisterRoute(route *types.Route) error {
+	routeJSON, err := json.Marshal(route)
+	if err != nil {
+		return err
+	}
+	_, err = client.HSet(RouteKey, routeField(route.Hostname, route.Path), routeJSON).Result()
+	return err
+}
+
+// FetchAllRoutes returns the path based routes of all applications
+func FetchAllRoutes() ([]*types.Route, error) {
+	data, err := client.HGetAll(RouteKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	routes := make([]*types.Route, 0, len(data))
+	for _, routeJSON := range data {
+		route := &types.Route{}
+		if err := json.Unmarshal([]byte(routeJSON), route); err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// RemoveRoutes removes path based routes from the routes HashMap
+func RemoveRoutes(routes ...*types.Route) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, route := range routes {
+			pipe.HDel(RouteKey, routeField(route.Hostname, route.Path))
+		}
+		return nil
+	})
+	return err
+}
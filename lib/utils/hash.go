@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -24,3 +27,12 @@ func CompareHashWithPassword(hashedPassword, password string) bool {
 	}
 	return true
 }
+
+// GenerateSecret returns a random hex encoded secret of the given number of bytes
+func GenerateSecret(length int) (string, error) {
+	secret := make([]byte, length)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/miekg/dns"
@@ -43,6 +44,18 @@ func NotAlive(url string) bool {
 	return false
 }
 
+// IsHealthy checks if an application instance responds successfully over HTTP
+// The check is the same as the one performed by the application container's health check
+func IsHealthy(url string) bool {
+	client := http.Client{Timeout: 10 * time.Second}
+	res, err := client.Get(fmt.Sprintf("http://%s/", url))
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode < 400
+}
+
 // IsGenDNSAlive checks if a GenDNS instance is alive or not
 func IsGenDNSAlive(url string) bool {
 	target := fmt.Sprintf("%s.%s", types.Master, configs.GasperConfig.Domain)
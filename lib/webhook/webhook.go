@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+const (
+	// GitHub is the name of the GitHub webhook provider
+	GitHub = "github"
+
+	// GitLab is the name of the GitLab webhook provider
+	GitLab = "gitlab"
+
+	// Gitea is the name of the Gitea webhook provider
+	Gitea = "gitea"
+
+	// branchRefPrefix is the prefix of the git reference of a branch
+	branchRefPrefix = "refs/heads/"
+
+	// nullCommit is the commit SHA sent by providers when a branch is deleted
+	nullCommit = "0000000000000000000000000000000000000000"
+)
+
+var (
+	// ErrUnknownProvider is the error when the request is not from a supported git hosting provider
+	ErrUnknownProvider = errors.New("Webhook request is not from GitHub, GitLab or Gitea")
+
+	// ErrInvalidSignature is the error when the signature of the request doesn't match the payload
+	ErrInvalidSignature = errors.New("Webhook signature is missing or invalid")
+)
+
+// Push stores the information extracted from a webhook request
+type Push struct {
+	Provider   string
+	Event      string
+	DeliveryID string
+	Ref        string `json:"ref"`
+	Commit     string `json:"after"`
+}
+
+// IsPush checks whether the request was sent for a push event
+func (p *Push) IsPush() bool {
+	switch p.Provider {
+	case GitLab:
+		return p.Event == "Push Hook"
+	default:
+		return p.Event == "push"
+	}
+}
+
+// Branch returns the branch to which the commits were pushed
+// An empty string is returned for pushes to tags or for deleted branches
+func (p *Push) Branch() string {
+	if !strings.HasPrefix(p.Ref, branchRefPrefix) || p.Commit == nullCommit {
+		return ""
+	}
+	return strings.TrimPrefix(p.Ref, branchRefPrefix)
+}
+
+// checkHMAC verifies a hex encoded HMAC signature of the payload
+func checkHMAC(hashFunc func() hash.Hash, payload []byte, secret, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// verify checks the authenticity of the request according to its provider
+// GitHub and Gitea sign the payload with HMAC whereas GitLab sends the secret token as it is
+func verify(provider string, header http.Header, payload []byte, secret string) bool {
+	switch provider {
+	case GitHub:
+		if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+			return strings.HasPrefix(signature, "sha256=") &&
+				checkHMAC(sha256.New, payload, secret, strings.TrimPrefix(signature, "sha256="))
+		}
+		signature := header.Get("X-Hub-Signature")
+		return strings.HasPrefix(signature, "sha1=") &&
+			checkHMAC(sha1.New, payload, secret, strings.TrimPrefix(signature, "sha1="))
+	case GitLab:
+		token := header.Get("X-Gitlab-Token")
+		return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	case Gitea:
+		return checkHMAC(sha256.New, payload, secret, header.Get("X-Gitea-Signature"))
+	}
+	return false
+}
+
+// Parse identifies the provider of a webhook request, verifies its signature with
+// the given secret and extracts the push information from its payload
+// The returned push holds the provider and event details even if verification fails
+func Parse(header http.Header, payload []byte, secret string) (*Push, error) {
+	push := &Push{}
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		push.Provider = Gitea
+		push.Event = header.Get("X-Gitea-Event")
+		push.DeliveryID = header.Get("X-Gitea-Delivery")
+	case header.Get("X-GitHub-Event") != "":
+		push.Provider = GitHub
+		push.Event = header.Get("X-GitHub-Event")
+		push.DeliveryID = header.Get("X-GitHub-Delivery")
+	case header.Get("X-Gitlab-Event") != "":
+		push.Provider = GitLab
+		push.Event = header.Get("X-Gitlab-Event")
+		push.DeliveryID = header.Get("X-Gitlab-Event-UUID")
+	default:
+		return push, ErrUnknownProvider
+	}
+
+	if !verify(push.Provider, header, payload, secret) {
+		return push, ErrInvalidSignature
+	}
+
+	if !push.IsPush() {
+		return push, nil
+	}
+	if err := json.Unmarshal(payload, push); err != nil {
+		return push, err
+	}
+	return push, nil
+}
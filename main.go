@@ -7,6 +7,7 @@ import (
 	"github.com/sdslabs/gasper/configs"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/services/appmaker"
+	"github.com/sdslabs/gasper/services/dbmaker"
 	"github.com/sdslabs/gasper/services/gendns"
 	"github.com/sdslabs/gasper/services/genproxy"
 	"github.com/sdslabs/gasper/services/master"
@@ -16,25 +17,42 @@ import (
 func initMaster() {
 	go master.ScheduleServiceExposure()
 	if configs.ServiceConfig.Master.Deploy {
+		go master.ScheduleLeaderElection()
 		go master.ScheduleCleanup()
+		go master.ScheduleRescheduling()
 	}
 }
 
 func initAppMaker() {
 	if configs.ServiceConfig.AppMaker.Deploy {
 		go appmaker.ScheduleMetricsCollection()
+		go appmaker.ScheduleReconciliation()
+		go appmaker.ScheduleIdleCheck()
+	}
+}
+
+func initDbMaker() {
+	if configs.ServiceConfig.DbMaker.Deploy {
+		go dbmaker.ScheduleReconciliation()
 	}
 }
 
 func initGenDNS() {
 	if configs.ServiceConfig.GenDNS.Deploy {
 		go gendns.ScheduleUpdate()
+		go gendns.SubscribeUpdates()
 	}
 }
 
 func initGenProxy() {
 	if configs.ServiceConfig.GenProxy.Deploy {
 		go genproxy.ScheduleUpdate()
+		go genproxy.SubscribeUpdates()
+		go genproxy.ScheduleTrafficFlush()
+		go genproxy.ScheduleHealthChecks()
+		if configs.ServiceConfig.GenProxy.SSL.PlugIn && configs.ServiceConfig.GenProxy.SSL.ACME.PlugIn {
+			go genproxy.ScheduleCertificateRenewal()
+		}
 	}
 }
 
@@ -55,6 +73,7 @@ func initServices() {
 func main() {
 	initMaster()
 	initAppMaker()
+	initDbMaker()
 	initGenDNS()
 	initGenProxy()
 	initServices()
@@ -119,10 +119,13 @@ func startGenSSHService() error {
 }
 
 func startGenProxyServiceWithSSL() error {
-	port := configs.ServiceConfig.GenProxy.SSL.Port
-	certificate := configs.ServiceConfig.GenProxy.SSL.Certificate
-	privateKey := configs.ServiceConfig.GenProxy.SSL.PrivateKey
-	err := buildHTTPServer(genproxy.NewService(), port).ListenAndServeTLS(certificate, privateKey)
+	tlsConfig, err := genproxy.NewTLSConfig()
+	if err == nil {
+		server := buildHTTPServer(genproxy.NewService(), configs.ServiceConfig.GenProxy.SSL.Port)
+		server.TLSConfig = tlsConfig
+		// The certificates are picked per SNI hostname by the TLS configuration
+		err = server.ListenAndServeTLS("", "")
+	}
 	if err != nil {
 		utils.Log("Main-Launchers-2", "There was a problem deploying GenProxy Service with SSL", utils.ErrorTAG)
 		utils.Log("Main-Launchers-3", "Make sure the paths of certificate and private key are correct in `config.toml`", utils.ErrorTAG)
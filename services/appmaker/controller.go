@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/api"
 	"github.com/sdslabs/gasper/lib/cloudflare"
 	"github.com/sdslabs/gasper/lib/docker"
 	"github.com/sdslabs/gasper/lib/factory"
@@ -51,11 +52,35 @@ func (s *server) Create(ctx context.Context, body *pb.RequestBody) (*pb.Response
 	if pipeline[language] == nil {
 		return nil, fmt.Errorf("Language `%s` is not supported", language)
 	}
-	resErr := pipeline[language].create(app)
+
+	// The application is stored before its repository is cloned so that its state can be tracked
+	app.SetState(types.AppCloning)
+	err = mongo.UpsertInstance(
+		types.M{
+			mongo.NameKey:         app.GetName(),
+			mongo.InstanceTypeKey: mongo.AppInstance,
+		}, app)
+
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	resErr := pipeline[language].create(app, api.NewDeployment(app.GetName()))
 	if resErr != nil {
+		// An application whose image failed to build is kept in the failed state without
+		// being served so that the logs of its build can be viewed, otherwise it is removed
+		if app.GetState() == types.AppBuilding {
+			utils.LogError("AppMaker-Controller-3", resErr)
+			go diskCleanup(app.GetName())
+			if err := mongo.UpdateAppState(app.GetName(), types.AppFailed); err != nil {
+				utils.LogError("AppMaker-Controller-6", err)
+			}
+			return nil, fmt.Errorf("Image of application %s failed to build: %s", app.GetName(), resErr.Message())
+		}
 		if resErr.Message() != "repository already exists" && resErr.Message() != "container already exists" {
 			go diskCleanup(app.GetName())
 		}
+		go stateCleanup(app.GetName())
 		return nil, fmt.Errorf(resErr.Error())
 	}
 
@@ -71,6 +96,7 @@ func (s *server) Create(ctx context.Context, body *pb.RequestBody) (*pb.Response
 		resp, err := cloudflare.CreateApplicationRecord(app.GetName())
 		if err != nil {
 			go diskCleanup(app.GetName())
+			go stateCleanup(app.GetName())
 			return nil, err
 		}
 		app.SetCloudflareID(resp.Result.ID)
@@ -115,43 +141,85 @@ func (s *server) Create(ctx context.Context, body *pb.RequestBody) (*pb.Response
 	app.SetSuccess(true)
 
 	response, err := json.Marshal(app)
-	return &pb.ResponseBody{Data: response}, err
-}
 
-// Rebuild rebuilds an application
-func (s *server) Rebuild(ctx context.Context, body *pb.NameHolder) (*pb.ResponseBody, error) {
-	appName := body.GetName()
+	// The application is built only after it has been stored so that
+	// its state and build records can be tracked
+	go deployApplication(app, body.GetOwner())
 
-	app, err := mongo.FetchSingleApp(appName)
-	if err != nil {
-		return nil, err
-	}
+	return &pb.ResponseBody{Data: response}, err
+}
 
-	diskCleanup(appName)
+// redeploy deploys an application in a new container alongside the running one
+// The new container replaces the running one only after it becomes healthy, until then
+// its lifecycle state is recorded as the state of the rebuild
+func redeploy(app *types.ApplicationConfig, deployment *api.Deployment, triggeredBy, rollbackOf string) (*pb.ResponseBody, error) {
+	appName := app.GetName()
 
 	if pipeline[app.Language] == nil {
 		return nil, fmt.Errorf("Non-supported language `%s` specified for `%s`", app.Language, appName)
 	}
 
-	resErr := pipeline[app.Language].create(app)
+	// Rebuilds of the same application share the candidate deployment hence only one can run at a time
+	if !startRebuild(appName) {
+		return nil, fmt.Errorf("Application %s is already being rebuilt", appName)
+	}
+
+	// Remove the leftovers of a previous redeploy which didn't complete
+	deploymentCleanup(deployment)
+
+	live := *app
+	if err := mongo.UpdateAppRebuildState(appName, types.AppCloning); err != nil {
+		utils.LogError("AppMaker-Controller-4", err)
+	}
+	resErr := pipeline[app.Language].create(app, deployment)
 	if resErr != nil {
-		if resErr.Message() != "repository already exists" && resErr.Message() != "container not created" {
-			go diskCleanup(app.GetName())
+		go deploymentCleanup(deployment)
+		finishRebuild(appName)
+		if err := mongo.UpdateAppRebuildState(appName, types.AppFailed); err != nil {
+			utils.LogError("AppMaker-Controller-2", err)
 		}
 		return nil, fmt.Errorf(resErr.Error())
 	}
-
-	err = mongo.UpdateInstance(types.M{mongo.NameKey: appName}, app)
-	if err != nil {
-		return nil, err
+	if err := mongo.UpdateAppRebuildState(appName, app.GetState()); err != nil {
+		utils.LogError("AppMaker-Controller-5", err)
 	}
 
 	app.SetSuccess(true)
 
 	response, err := json.Marshal(app)
+
+	go promoteDeployment(&live, app, deployment, triggeredBy, rollbackOf)
+
 	return &pb.ResponseBody{Data: response}, err
 }
 
+// Rebuild rebuilds an application from the latest commit of its branch
+func (s *server) Rebuild(ctx context.Context, body *pb.RebuildRequest) (*pb.ResponseBody, error) {
+	app, err := mongo.FetchSingleApp(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	_, candidate := api.Deployments(app)
+	return redeploy(app, candidate, body.GetTriggeredBy(), "")
+}
+
+// Rollback redeploys a release of an application with the exact commit, image and configuration of that release
+func (s *server) Rollback(ctx context.Context, body *pb.RollbackRequest) (*pb.ResponseBody, error) {
+	app, err := mongo.FetchSingleApp(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	release, err := mongo.FetchSingleRelease(body.GetName(), body.GetRelease())
+	if err != nil {
+		return nil, err
+	}
+	_, candidate := api.Deployments(app)
+	candidate.Commit = release.Commit
+	candidate.Image = release.DockerImage
+	release.ApplyTo(app)
+	return redeploy(app, candidate, body.GetTriggeredBy(), release.ID)
+}
+
 // Delete deletes an application
 func (s *server) Delete(ctx context.Context, body *pb.NameHolder) (*pb.DeletionResponse, error) {
 	appName := body.GetName()
@@ -160,10 +228,20 @@ func (s *server) Delete(ctx context.Context, body *pb.NameHolder) (*pb.DeletionR
 		mongo.InstanceTypeKey: mongo.AppInstance,
 	}
 
-	node, _ := redis.FetchAppNode(appName)
-	go redis.DecrementServiceLoad(ServiceName, node)
+	// An application whose image failed to build was never registered
+	if node, err := redis.FetchAppNode(appName); err == nil {
+		go redis.DecrementServiceLoad(ServiceName, node)
+	}
 	go redis.RemoveApp(appName)
 	go diskCleanup(appName)
+	go mongo.DeleteBuilds(types.M{mongo.NameKey: appName})
+	go mongo.DeleteTraffic(types.M{mongo.NameKey: appName})
+	go mongo.DeleteWebhook(types.M{mongo.NameKey: appName})
+	go mongo.DeleteWebhookDeliveries(types.M{mongo.NameKey: appName})
+	go mongo.DeleteReleases(types.M{mongo.NameKey: appName})
+	go mongo.DeleteDeployKey(types.M{mongo.NameKey: appName})
+	go domainCleanup(appName)
+	go routeCleanup(appName)
 
 	if configs.CloudflareConfig.PlugIn {
 		go cloudflare.DeleteRecord(appName, mongo.AppInstance)
@@ -176,6 +254,64 @@ func (s *server) Delete(ctx context.Context, body *pb.NameHolder) (*pb.DeletionR
 	return &pb.DeletionResponse{Success: true}, nil
 }
 
+// Evict removes the container and local storage of an application which has been moved to another node
+// The application's state is left intact as it is now held by the node to which it was moved
+func (s *server) Evict(ctx context.Context, body *pb.NameHolder) (*pb.DeletionResponse, error) {
+	appName := body.GetName()
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if app.HostIP == utils.HostIP {
+		return nil, fmt.Errorf("Application %s has not been moved from this node", appName)
+	}
+
+	// The application itself shares its name with its replicas, hence
+	// the container is removed only if no replica is present on the node
+	if len(mongo.FetchReplicas(replicaFilter(appName))) != 0 {
+		return nil, fmt.Errorf("A replica of application %s is present on this node", appName)
+	}
+
+	go redis.DecrementServiceLoad(ServiceName, currentNode())
+	go diskCleanup(appName)
+
+	return &pb.DeletionResponse{Success: true}, nil
+}
+
+// Relocate deploys an application which is moved from another node on the current node with the commit,
+// docker image and lifecycle state it had there, the application keeps its releases and replicas
+func (s *server) Relocate(ctx context.Context, body *pb.NameHolder) (*pb.ResponseBody, error) {
+	appName := body.GetName()
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if app.HostIP == utils.HostIP {
+		return nil, fmt.Errorf("Application %s is already deployed on this node", appName)
+	}
+	if len(mongo.FetchReplicas(replicaFilter(appName))) != 0 {
+		return nil, fmt.Errorf("A replica of application %s is present on this node", appName)
+	}
+
+	app.SetHostIP(utils.HostIP)
+	sshEntrypointIP := configs.ServiceConfig.GenSSH.EntrypointIP
+	if len(sshEntrypointIP) == 0 {
+		sshEntrypointIP = utils.HostIP
+	}
+	app.SetSSHCmd(configs.ServiceConfig.GenSSH.Port, appName, sshEntrypointIP)
+
+	if err := deployCurrentCommit(app); err != nil {
+		return nil, err
+	}
+	if err := redis.IncrementServiceLoad(ServiceName, currentNode()); err != nil {
+		utils.LogError("AppMaker-Controller-7", err)
+	}
+
+	app.SetSuccess(true)
+	response, err := json.Marshal(app)
+	return &pb.ResponseBody{Data: response}, err
+}
+
 // FetchLogs returns the docker container logs of an application
 func (s *server) FetchLogs(ctx context.Context, body *pb.LogRequest) (*pb.LogResponse, error) {
 	appName := body.GetName()
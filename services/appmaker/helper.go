@@ -1,10 +1,11 @@
 package appmaker
 
 import (
-	"fmt"
 	"os"
-	"path/filepath"
 
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/cloudflare"
 	"github.com/sdslabs/gasper/lib/docker"
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/redis"
@@ -12,8 +13,6 @@ import (
 	"github.com/sdslabs/gasper/types"
 )
 
-var path, _ = os.Getwd()
-
 // storageCleanup removes the application's local storage directory
 func storageCleanup(path string) error {
 	err := os.RemoveAll(path)
@@ -34,15 +33,25 @@ func containerCleanup(appName string) error {
 
 // diskCleanup cleans the specified application's container and local storage
 func diskCleanup(appName string) {
-	appDir := filepath.Join(path, fmt.Sprintf("storage/%s", appName))
+	appDirs := api.StorageDirs(appName)
 	storeCleanupChan := make(chan error)
 	go func() {
-		storeCleanupChan <- storageCleanup(appDir)
+		for _, appDir := range appDirs {
+			storageCleanup(appDir)
+		}
+		storeCleanupChan <- nil
 	}()
 	containerCleanup(appName)
 	<-storeCleanupChan
 }
 
+// deploymentCleanup removes the container and local storage of an application's deployment
+// Errors are ignored as the deployment may only have been partially created
+func deploymentCleanup(deployment *api.Deployment) {
+	docker.DeleteContainer(deployment.ContainerName)
+	os.RemoveAll(deployment.StoreDir)
+}
+
 // stateCleanup removes the application's data from MongoDB and Redis
 func stateCleanup(appName string) {
 	_, err := mongo.DeleteInstance(types.M{
@@ -56,3 +65,48 @@ func stateCleanup(appName string) {
 		utils.LogError("AppMaker-Helper-4", err)
 	}
 }
+
+// domainCleanup removes the custom domains attached to the application
+func domainCleanup(appName string) {
+	for _, domain := range mongo.FetchDomains(types.M{mongo.NameKey: appName}) {
+		hostname, ok := domain[mongo.HostnameKey].(string)
+		if !ok {
+			continue
+		}
+		if err := redis.RemoveDomain(hostname); err != nil {
+			utils.LogError("AppMaker-Helper-5", err)
+			continue
+		}
+		if _, err := mongo.DeleteDomain(types.M{mongo.HostnameKey: hostname}); err != nil {
+			utils.LogError("AppMaker-Helper-6", err)
+			continue
+		}
+		if verified, _ := domain["verified"].(bool); verified && configs.CloudflareConfig.PlugIn {
+			if _, err := cloudflare.DeleteCustomHostname(hostname); err != nil {
+				utils.LogError("AppMaker-Helper-7", err)
+			}
+		}
+	}
+}
+
+// routeCleanup removes the routes on the hostnames of the application and those serving the application
+func routeCleanup(appName string) {
+	filter := types.M{
+		"$or": []types.M{
+			{mongo.NameKey: appName},
+			{mongo.TargetKey: appName},
+		},
+	}
+	routes, err := mongo.FetchRoutes(filter)
+	if err != nil {
+		utils.LogError("AppMaker-Helper-8", err)
+		return
+	}
+	if err := redis.RemoveRoutes(routes...); err != nil {
+		utils.LogError("AppMaker-Helper-9", err)
+		return
+	}
+	if _, err := mongo.DeleteRoutes(filter); err != nil {
+		utils.LogError("AppMaker-Helper-10", err)
+	}
+}
@@ -0,0 +1,94 @@
+package appmaker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sdslabs/gasper/lib/factory"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/application"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// idleCheckInterval is the time interval between consecutive checks for idle applications
+const idleCheckInterval = time.Minute
+
+// sleepApplication puts an idle application to sleep by stopping its container on the current node
+// and those of its replicas on other nodes
+// The application is marked as sleeping only after its containers have been stopped so that GenProxy
+// wakes it up on the next request
+func sleepApplication(appName string) error {
+	bindings, err := redis.FetchAppBindings(appName)
+	if err != nil {
+		return err
+	}
+	if bindings.Stopped || bindings.Sleeping {
+		return nil
+	}
+	if _, err := (&server{}).Sleep(context.Background(), &pb.NameHolder{Name: appName}); err != nil {
+		return err
+	}
+	for _, replica := range bindings.Replicas {
+		if _, err := factory.SleepApplication(appName, replica.Node); err != nil {
+			utils.LogError("AppMaker-Idler-1", err)
+		}
+	}
+	return redis.MarkAppSleeping(appName, true)
+}
+
+// checkIdleApplications puts the running applications of the current node which have an idle timeout
+// and haven't received any request within it to sleep
+func checkIdleApplications() {
+	apps := mongo.FetchAppInfo(types.M{
+		mongo.HostIPKey:      utils.HostIP,
+		mongo.StateKey:       types.AppRunning,
+		mongo.IdleTimeoutKey: types.M{"$gt": 0},
+	})
+	if len(apps) == 0 {
+		return
+	}
+
+	activity, err := redis.FetchAppActivity()
+	if err != nil {
+		utils.LogError("AppMaker-Idler-2", err)
+		return
+	}
+
+	now := time.Now()
+	for _, info := range apps {
+		appName, ok := info[mongo.NameKey].(string)
+		if !ok {
+			continue
+		}
+		lastRequest, found := activity[appName]
+		if !found {
+			// Applications which haven't received any request yet are given the whole idle timeout
+			if err := redis.InitAppActivity(appName, now); err != nil {
+				utils.LogError("AppMaker-Idler-3", err)
+			}
+			continue
+		}
+		app, err := mongo.FetchSingleApp(appName)
+		if err != nil {
+			utils.LogError("AppMaker-Idler-4", err)
+			continue
+		}
+		if now.Sub(time.Unix(lastRequest, 0)) < app.GetIdleTimeout() {
+			continue
+		}
+		if err := sleepApplication(appName); err != nil {
+			utils.LogError("AppMaker-Idler-5", fmt.Errorf("Failed to put application %s to sleep: %s", appName, err))
+			continue
+		}
+		utils.LogInfo("AppMaker-Idler-6", "Application %s has been put to sleep after receiving no requests", appName)
+	}
+}
+
+// ScheduleIdleCheck periodically puts the idle applications of the current node to sleep
+func ScheduleIdleCheck() {
+	scheduler := utils.NewScheduler(idleCheckInterval, checkIdleApplications)
+	scheduler.RunAsync()
+}
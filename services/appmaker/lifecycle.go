@@ -0,0 +1,113 @@
+package appmaker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/docker"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/application"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// localInstance returns an application present on the current node along with the function updating the
+// lifecycle state of the application or of its replica, whichever of them is present on the node
+func localInstance(appName string) (*types.ApplicationConfig, func(string) error, error) {
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The container of the application or of its replica is named after the application
+	app.SetContainerID(appName)
+
+	if app.HostIP == utils.HostIP {
+		return app, func(state string) error {
+			return mongo.UpdateAppState(appName, state)
+		}, nil
+	}
+	if len(mongo.FetchReplicas(replicaFilter(appName))) == 0 {
+		return nil, nil, fmt.Errorf("Application %s is not present on this node", appName)
+	}
+	return app, func(state string) error {
+		return mongo.UpdateReplicaState(appName, utils.HostIP, state)
+	}, nil
+}
+
+// runApplication executes the run commands of an application whose container has been started again
+// Applications built from a Dockerfile are run by their image when their container starts
+func runApplication(app *types.ApplicationConfig) error {
+	if app.Language == types.Docker {
+		return nil
+	}
+	return api.Run(app)
+}
+
+// Start starts the stopped container of an application or its replica present on the current node
+// A container which is already running is left as it is so that concurrent starts of a sleeping
+// application don't run it twice
+func (s *server) Start(ctx context.Context, body *pb.NameHolder) (*pb.GenericResponse, error) {
+	app, setState, err := localInstance(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if state, err := docker.InspectContainerState(app.GetContainerID()); err == nil && state.Running {
+		return &pb.GenericResponse{Success: true}, setState(types.AppRunning)
+	}
+	if err := docker.StartContainer(app.GetContainerID()); err != nil {
+		return nil, err
+	}
+	if err := runApplication(app); err != nil {
+		if err := setState(types.AppFailed); err != nil {
+			utils.LogError("AppMaker-Lifecycle-1", err)
+		}
+		return nil, err
+	}
+	return &pb.GenericResponse{Success: true}, setState(types.AppRunning)
+}
+
+// Stop stops the container of an application or its replica present on the current node
+// The container and its storage are kept so that the application can be started again
+func (s *server) Stop(ctx context.Context, body *pb.NameHolder) (*pb.GenericResponse, error) {
+	app, setState, err := localInstance(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := docker.StopContainer(app.GetContainerID()); err != nil {
+		return nil, err
+	}
+	return &pb.GenericResponse{Success: true}, setState(types.AppStopped)
+}
+
+// Restart restarts the container of an application or its replica present on the current node
+func (s *server) Restart(ctx context.Context, body *pb.NameHolder) (*pb.GenericResponse, error) {
+	app, setState, err := localInstance(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := docker.RestartContainer(app.GetContainerID()); err != nil {
+		return nil, err
+	}
+	if err := runApplication(app); err != nil {
+		if err := setState(types.AppFailed); err != nil {
+			utils.LogError("AppMaker-Lifecycle-2", err)
+		}
+		return nil, err
+	}
+	return &pb.GenericResponse{Success: true}, setState(types.AppRunning)
+}
+
+// Sleep stops the container of an idle application or its replica present on the current node
+// The application is started again by the next request to it
+func (s *server) Sleep(ctx context.Context, body *pb.NameHolder) (*pb.GenericResponse, error) {
+	app, setState, err := localInstance(body.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := docker.StopContainer(app.GetContainerID()); err != nil {
+		return nil, err
+	}
+	return &pb.GenericResponse{Success: true}, setState(types.AppSleeping)
+}
@@ -10,13 +10,23 @@ import (
 type applicationHandler struct {
 	image         string
 	confGenerator func(string, string) string
+	// dockerfile denotes that the application's image is built from
+	// the Dockerfile present in its git repository
+	dockerfile bool
 }
 
-// create handles the creation of a new application
-func (handler *applicationHandler) create(app *types.ApplicationConfig) types.ResponseError {
-	app.SetDockerImage(handler.image)
+// create handles the creation of a new application in the given deployment
+func (handler *applicationHandler) create(app *types.ApplicationConfig, deployment *api.Deployment) types.ResponseError {
+	if deployment.Image != "" {
+		app.SetDockerImage(deployment.Image)
+	} else {
+		app.SetDockerImage(handler.image)
+	}
 	app.SetConfGenerator(handler.confGenerator)
-	return api.SetupApplication(app)
+	if handler.dockerfile {
+		return api.SetupDockerfileApplication(app, deployment)
+	}
+	return api.SetupApplication(app, deployment)
 }
 
 var pipeline = map[string]*applicationHandler{
@@ -46,4 +56,7 @@ var pipeline = map[string]*applicationHandler{
 		image:         configs.ImageConfig.Static,
 		confGenerator: configs.CreateStaticContainerConfig,
 	},
+	types.Docker: {
+		dockerfile: true,
+	},
 }
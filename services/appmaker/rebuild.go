@@ -0,0 +1,130 @@
+package appmaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/docker"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// defaultHealthCheckTimeout is the time for which a rebuilt application is given
+	// to become healthy when no timeout is configured
+	defaultHealthCheckTimeout = 300 * time.Second
+
+	// healthCheckInterval is the time interval between consecutive health checks of a rebuilt application
+	healthCheckInterval = 5 * time.Second
+)
+
+var (
+	// rebuilding holds the names of the applications being rebuilt on the current node
+	rebuilding      = make(map[string]bool)
+	rebuildingMutex sync.Mutex
+)
+
+// startRebuild marks an application as being rebuilt
+// It returns false if the application is already being rebuilt
+func startRebuild(appName string) bool {
+	rebuildingMutex.Lock()
+	defer rebuildingMutex.Unlock()
+	if rebuilding[appName] {
+		return false
+	}
+	rebuilding[appName] = true
+	return true
+}
+
+// finishRebuild marks the rebuild of an application as finished
+func finishRebuild(appName string) {
+	rebuildingMutex.Lock()
+	defer rebuildingMutex.Unlock()
+	delete(rebuilding, appName)
+}
+
+// waitUntilHealthy polls the application until it responds successfully or the health check timeout expires
+func waitUntilHealthy(app types.Application) bool {
+	timeout := configs.ServiceConfig.AppMaker.HealthCheckTimeout * time.Second
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	url := fmt.Sprintf("localhost:%d", app.GetContainerPort())
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(healthCheckInterval) {
+		if utils.IsHealthy(url) {
+			return true
+		}
+	}
+	return false
+}
+
+// promoteDeployment builds and starts the rebuilt application in its candidate deployment and
+// switches the application's traffic to it once it becomes healthy
+// The live deployment is removed only after the switch, hence if the rebuilt application
+// fails at any step the live deployment keeps serving the application and only the state
+// of the rebuild is marked as failed
+func promoteDeployment(app, rebuilt *types.ApplicationConfig, candidate *api.Deployment, triggeredBy, rollbackOf string) {
+	appName := app.GetName()
+	defer finishRebuild(appName)
+
+	api.BuildAndRunRebuild(rebuilt)
+	if rebuilt.GetState() != types.AppRunning {
+		utils.Log("AppMaker-Rebuild-1", fmt.Sprintf("Rebuild of application %s failed, keeping the previous container", appName), utils.ErrorTAG)
+		deploymentCleanup(candidate)
+		return
+	}
+
+	if !waitUntilHealthy(rebuilt) {
+		utils.Log("AppMaker-Rebuild-2", fmt.Sprintf("Rebuilt application %s never became healthy, keeping the previous container", appName), utils.ErrorTAG)
+		deploymentCleanup(candidate)
+		if err := mongo.UpdateAppRebuildState(appName, types.AppFailed); err != nil {
+			utils.LogError("AppMaker-Rebuild-3", err)
+		}
+		return
+	}
+
+	err := redis.RegisterApp(
+		appName,
+		fmt.Sprintf("%s:%d", utils.HostIP, configs.ServiceConfig.AppMaker.Port),
+		fmt.Sprintf("%s:%d", utils.HostIP, rebuilt.GetContainerPort()),
+	)
+	if err != nil {
+		utils.LogError("AppMaker-Rebuild-4", err)
+		deploymentCleanup(candidate)
+		if err := mongo.UpdateAppRebuildState(appName, types.AppFailed); err != nil {
+			utils.LogError("AppMaker-Rebuild-5", err)
+		}
+		return
+	}
+
+	rebuilt.RebuildState = types.AppRunning
+	err = mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, rebuilt)
+	if err != nil {
+		utils.LogError("AppMaker-Rebuild-6", err)
+	}
+
+	// The previous container is no longer receiving traffic and the rebuilt container
+	// takes over its name so that it can be referred to by the application's name
+	if err := docker.DeleteContainer(app.GetContainerID()); err != nil {
+		utils.LogError("AppMaker-Rebuild-7", err)
+	}
+	for _, storedir := range api.StorageDirs(appName) {
+		if storedir != candidate.StoreDir {
+			storageCleanup(storedir)
+		}
+	}
+	if err := docker.RenameContainer(rebuilt.GetContainerID(), appName); err != nil {
+		utils.LogError("AppMaker-Rebuild-8", err)
+	}
+
+	recordRelease(rebuilt, triggeredBy, rollbackOf)
+	refreshReplicas(appName)
+}
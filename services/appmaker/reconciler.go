@@ -0,0 +1,282 @@
+package appmaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/docker"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/application"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// defaultReconcileInterval is the time interval between consecutive reconciliations
+	// when no interval is configured
+	defaultReconcileInterval = 300 * time.Second
+
+	// orphanGracePeriod is the age after which a container or storage directory not belonging to any
+	// application of the current node is considered orphaned, the deployments of applications being
+	// rebuilt or moved to the current node are stored only after their container has been created
+	orphanGracePeriod = 15 * time.Minute
+)
+
+// reconcileMutex stops reconciliations which act on their findings from overlapping
+var reconcileMutex sync.Mutex
+
+// reconcileContainer restarts the stopped container of an application or its replica
+// and recreates the container if it is missing or cannot be restarted
+func reconcileContainer(report *types.ReconcileReport, name string, states map[string]string, recreate func(string) error) {
+	state, found := states[name]
+	switch {
+	case !found || state == "dead":
+		report.Recreated = append(report.Recreated, name)
+		if report.DryRun {
+			return
+		}
+		if err := recreate(name); err != nil {
+			report.Fail(name, err)
+		}
+	case state == "exited" || state == "created":
+		report.Restarted = append(report.Restarted, name)
+		if report.DryRun {
+			return
+		}
+		if err := restartApplication(name); err != nil {
+			report.Fail(name, err)
+		}
+	}
+}
+
+// restartApplication starts the stopped container of an application or its replica and runs the application again
+func restartApplication(appName string) error {
+	app, _, err := localInstance(appName)
+	if err != nil {
+		return err
+	}
+	if err := docker.StartContainer(app.GetContainerID()); err != nil {
+		return err
+	}
+	return runApplication(app)
+}
+
+// deployCurrentCommit deploys an application on the current node with the commit and docker image it
+// is deployed with, the application keeps its releases and replicas
+// A stopped or sleeping application is stopped again once it has been built so that it stays that way
+func deployCurrentCommit(app *types.ApplicationConfig) error {
+	appName := app.GetName()
+	if pipeline[app.Language] == nil {
+		return fmt.Errorf("Non-supported language `%s` specified for `%s`", app.Language, appName)
+	}
+	state := app.GetState()
+
+	diskCleanup(appName)
+
+	deployment := api.NewDeployment(appName)
+	deployment.Commit = app.GetCommit()
+	deployment.Image = app.GetDockerImage()
+
+	resErr := pipeline[app.Language].create(app, deployment)
+	if resErr != nil {
+		go diskCleanup(appName)
+		return fmt.Errorf(resErr.Error())
+	}
+
+	err := mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, app)
+	if err != nil {
+		go diskCleanup(appName)
+		return err
+	}
+
+	err = redis.RelocateApp(appName, currentNode(), fmt.Sprintf("%s:%d", utils.HostIP, app.GetContainerPort()))
+	if err != nil {
+		go diskCleanup(appName)
+		return err
+	}
+
+	go func() {
+		api.BuildAndRun(app)
+		if (state == types.AppStopped || state == types.AppSleeping) && app.GetState() != types.AppFailed {
+			if err := docker.StopContainer(app.GetContainerID()); err != nil {
+				utils.LogError("AppMaker-Reconciler-5", err)
+				return
+			}
+			if err := mongo.UpdateAppState(appName, state); err != nil {
+				utils.LogError("AppMaker-Reconciler-6", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// recreateApplication deploys an application whose container has gone missing on the current node
+// again with the application's current commit
+func recreateApplication(appName string) error {
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return err
+	}
+	return deployCurrentCommit(app)
+}
+
+// recreateReplica places the replica of an application whose container has gone missing
+// on the current node again
+func recreateReplica(appName string) error {
+	_, err := (&server{}).CreateReplica(context.Background(), &pb.NameHolder{Name: appName})
+	return err
+}
+
+// findOrphans returns the application containers and storage directories on the current node
+// which don't belong to any of the given applications
+func findOrphans(report *types.ReconcileReport, appNames []string) {
+	expectedContainers := make(map[string]bool)
+	expectedStorage := make(map[string]bool)
+	for _, appName := range appNames {
+		for _, containerName := range api.ContainerNames(appName) {
+			expectedContainers[containerName] = true
+		}
+		for _, storedir := range api.StorageDirs(appName) {
+			expectedStorage[storedir] = true
+		}
+	}
+
+	containers, err := docker.ListLabelledContainers(docker.ApplicationLabel)
+	if err != nil {
+		report.Fail(ServiceName, err)
+	}
+	for containerName, createdAt := range containers {
+		if !expectedContainers[containerName] && time.Since(createdAt) > orphanGracePeriod {
+			report.OrphanContainers = append(report.OrphanContainers, containerName)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(api.StorageRoot())
+	if err != nil && !os.IsNotExist(err) {
+		report.Fail(ServiceName, err)
+	}
+	for _, entry := range entries {
+		storedir := filepath.Join(api.StorageRoot(), entry.Name())
+		if entry.IsDir() && !expectedStorage[storedir] && time.Since(entry.ModTime()) > orphanGracePeriod {
+			report.OrphanStorage = append(report.OrphanStorage, storedir)
+		}
+	}
+}
+
+// reconcile compares the applications and replicas stored for the current node with the containers
+// and storage directories present on it
+// Stopped containers of running applications are restarted and missing ones are recreated, orphaned
+// containers and storage directories are removed only if garbage collection is enabled
+func reconcile(dryRun bool) *types.ReconcileReport {
+	if !dryRun {
+		reconcileMutex.Lock()
+		defer reconcileMutex.Unlock()
+	}
+
+	report := types.NewReconcileReport(utils.HostIP, ServiceName, dryRun)
+
+	states, err := docker.ListContainerStates()
+	if err != nil {
+		report.Fail(ServiceName, err)
+		return report
+	}
+
+	nodeAppFilter := types.M{
+		mongo.HostIPKey:       utils.HostIP,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}
+	nodeReplicaFilter := types.M{
+		mongo.HostIPKey: utils.HostIP,
+	}
+	appNames, err := mongo.FetchNames(mongo.InstanceCollection, nodeAppFilter)
+	if err != nil {
+		report.Fail(ServiceName, err)
+		return report
+	}
+	replicaNames, err := mongo.FetchNames(mongo.ReplicaCollection, nodeReplicaFilter)
+	if err != nil {
+		report.Fail(ServiceName, err)
+		return report
+	}
+
+	// Applications which are being built, have failed or have been stopped by their owners
+	// are left to their own lifecycle
+	for _, app := range mongo.FetchAppInfo(nodeAppFilter) {
+		if name, ok := app[mongo.NameKey].(string); ok && app[mongo.StateKey] == types.AppRunning {
+			reconcileContainer(report, name, states, recreateApplication)
+		}
+	}
+	for _, replica := range mongo.FetchReplicas(nodeReplicaFilter) {
+		if name, ok := replica[mongo.NameKey].(string); ok && replica[mongo.StateKey] == types.AppRunning {
+			reconcileContainer(report, name, states, recreateReplica)
+		}
+	}
+
+	findOrphans(report, append(appNames, replicaNames...))
+	if report.DryRun || !configs.ServiceConfig.AppMaker.CollectGarbage {
+		return report
+	}
+	for _, containerName := range report.OrphanContainers {
+		if err := containerCleanup(containerName); err != nil {
+			report.Fail(containerName, err)
+		}
+	}
+	for _, storedir := range report.OrphanStorage {
+		if err := storageCleanup(storedir); err != nil {
+			report.Fail(storedir, err)
+		}
+	}
+	report.Collected = true
+	return report
+}
+
+// logReconcileReport logs the actions taken by a reconciliation
+func logReconcileReport(report *types.ReconcileReport) {
+	for _, name := range report.Restarted {
+		utils.LogInfo("AppMaker-Reconciler-1", "Restarted the stopped container of application %s", name)
+	}
+	for _, name := range report.Recreated {
+		utils.LogInfo("AppMaker-Reconciler-2", "Recreated the missing container of application %s", name)
+	}
+	if !report.Collected && len(report.OrphanContainers)+len(report.OrphanStorage) > 0 {
+		utils.LogInfo("AppMaker-Reconciler-3", "Found orphaned containers %v and storage directories %v",
+			report.OrphanContainers, report.OrphanStorage)
+	}
+	for name, err := range report.Errors {
+		utils.LogError("AppMaker-Reconciler-4", fmt.Errorf("Failed to reconcile %s: %s", name, err))
+	}
+}
+
+// Reconcile reconciles the application containers of the current node with the applications stored for it
+func (s *server) Reconcile(ctx context.Context, body *pb.ReconcileRequest) (*pb.ResponseBody, error) {
+	report := reconcile(body.GetDryRun())
+	if !report.DryRun {
+		logReconcileReport(report)
+	}
+	response, err := json.Marshal(report)
+	return &pb.ResponseBody{Data: response}, err
+}
+
+// ScheduleReconciliation runs the reconciler at the given reconcile interval
+func ScheduleReconciliation() {
+	interval := configs.ServiceConfig.AppMaker.ReconcileInterval * time.Second
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	scheduler := utils.NewScheduler(interval, func() {
+		logReconcileReport(reconcile(false))
+	})
+	scheduler.RunAsync()
+}
@@ -0,0 +1,43 @@
+package appmaker
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// recordRelease stores a successful deploy of an application as a release
+// rollbackOf holds the ID of the release which was redeployed (if any)
+func recordRelease(app *types.ApplicationConfig, triggeredBy, rollbackOf string) {
+	git := app.Git
+	git.AccessToken = ""
+	release := &types.Release{
+		ID:          uuid.New().String(),
+		Name:        app.GetName(),
+		Commit:      app.GetCommit(),
+		DockerImage: app.GetDockerImage(),
+		Git:         git,
+		Context:     app.Context,
+		Resources:   app.Resources,
+		Env:         app.Env,
+		TriggeredBy: triggeredBy,
+		RollbackOf:  rollbackOf,
+		Timestamp:   time.Now().Unix(),
+	}
+	if _, err := mongo.RegisterRelease(release); err != nil {
+		utils.LogError("AppMaker-Release-1", err)
+	}
+}
+
+// deployApplication builds and starts a newly created application
+// and records it as a release once it is running
+func deployApplication(app *types.ApplicationConfig, triggeredBy string) {
+	api.BuildAndRun(app)
+	if app.GetState() == types.AppRunning {
+		recordRelease(app, triggeredBy, "")
+	}
+}
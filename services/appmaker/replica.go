@@ -0,0 +1,136 @@
+package appmaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/api"
+	"github.com/sdslabs/gasper/lib/factory"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/application"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// currentNode returns the URL of the AppMaker instance on the current node
+func currentNode() string {
+	return fmt.Sprintf("%s:%d", utils.HostIP, configs.ServiceConfig.AppMaker.Port)
+}
+
+// replicaFilter returns the filter for the replica of an application deployed on the current node
+func replicaFilter(appName string) types.M {
+	return types.M{
+		mongo.NameKey:   appName,
+		mongo.HostIPKey: utils.HostIP,
+	}
+}
+
+// CreateReplica creates a replica of an application on the current node running the application's
+// current commit, a replica of the application already present on the node is replaced
+func (s *server) CreateReplica(ctx context.Context, body *pb.NameHolder) (*pb.ResponseBody, error) {
+	appName := body.GetName()
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return nil, err
+	}
+	if app.HostIP == utils.HostIP {
+		return nil, fmt.Errorf("Replica of application %s cannot be placed on the application's own node", appName)
+	}
+	if pipeline[app.Language] == nil {
+		return nil, fmt.Errorf("Non-supported language `%s` specified for `%s`", app.Language, appName)
+	}
+
+	replaced := len(mongo.FetchReplicas(replicaFilter(appName))) > 0
+	diskCleanup(appName)
+
+	deployment := api.NewDeployment(appName)
+	deployment.Commit = app.GetCommit()
+	// Images built from a Dockerfile are only present on the application's own node
+	// hence they are built again for the replica
+	if app.Language != types.Docker {
+		deployment.Image = app.GetDockerImage()
+	}
+	app.SetHostIP(utils.HostIP)
+
+	resErr := pipeline[app.Language].create(app, deployment)
+	if resErr != nil {
+		go diskCleanup(appName)
+		return nil, fmt.Errorf(resErr.Error())
+	}
+
+	replica := &types.Replica{
+		Name:          appName,
+		Node:          currentNode(),
+		HostIP:        utils.HostIP,
+		ContainerID:   app.GetContainerID(),
+		ContainerPort: app.GetContainerPort(),
+		Commit:        app.GetCommit(),
+		State:         app.GetState(),
+		Resources:     app.Resources,
+		CreatedAt:     time.Now().Unix(),
+	}
+
+	err = mongo.UpsertReplica(replicaFilter(appName), replica)
+	if err != nil && err != mongo.ErrNoDocuments {
+		go diskCleanup(appName)
+		return nil, err
+	}
+
+	err = redis.RegisterAppReplica(appName, replica.Node, replica.Server())
+	if err != nil {
+		go diskCleanup(appName)
+		go mongo.DeleteReplicas(replicaFilter(appName))
+		return nil, err
+	}
+
+	if !replaced {
+		if err := redis.IncrementServiceLoad(ServiceName, replica.Node); err != nil {
+			utils.LogError("AppMaker-Replica-1", err)
+		}
+	}
+
+	response, err := json.Marshal(replica)
+
+	go api.BuildAndRunReplica(app)
+
+	return &pb.ResponseBody{Data: response}, err
+}
+
+// DeleteReplica deletes the replica of an application present on the current node
+func (s *server) DeleteReplica(ctx context.Context, body *pb.NameHolder) (*pb.DeletionResponse, error) {
+	appName := body.GetName()
+
+	// The application itself shares its name with its replicas, hence
+	// the container is removed only if a replica is present on the node
+	if len(mongo.FetchReplicas(replicaFilter(appName))) == 0 {
+		return nil, fmt.Errorf("No replica of application %s is present on this node", appName)
+	}
+
+	go redis.DecrementServiceLoad(ServiceName, currentNode())
+	go redis.RemoveAppReplica(appName, currentNode())
+	go diskCleanup(appName)
+
+	_, err := mongo.DeleteReplicas(replicaFilter(appName))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeletionResponse{Success: true}, nil
+}
+
+// refreshReplicas recreates the replicas of an application so that they run the application's
+// current commit, the application itself keeps serving requests while its replicas are rebuilt
+func refreshReplicas(appName string) {
+	for _, replica := range mongo.FetchReplicas(types.M{mongo.NameKey: appName}) {
+		node, ok := replica[mongo.NodeKey].(string)
+		if !ok {
+			continue
+		}
+		if _, err := factory.CreateApplicationReplica(appName, node); err != nil {
+			utils.LogError("AppMaker-Replica-2", err)
+		}
+	}
+}
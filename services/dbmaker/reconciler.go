@@ -0,0 +1,242 @@
+package dbmaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/database"
+	"github.com/sdslabs/gasper/lib/docker"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/database"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// defaultReconcileInterval is the time interval between consecutive reconciliations
+	// when no interval is configured
+	defaultReconcileInterval = 300 * time.Second
+
+	// orphanGracePeriod is the age after which a container or storage directory not belonging to any
+	// database of the current node is considered orphaned, databases being created are stored
+	// only after their container has been created
+	orphanGracePeriod = 15 * time.Minute
+)
+
+// reconcileMutex stops reconciliations which act on their findings from overlapping
+var reconcileMutex sync.Mutex
+
+// databaseServers maps the database servers managed by DbMaker to whether they are deployed on the current node
+var databaseServers = map[string]bool{
+	types.MySQL:      configs.ServiceConfig.DbMaker.MySQL.PlugIn,
+	types.MongoDB:    configs.ServiceConfig.DbMaker.MongoDB.PlugIn,
+	types.PostgreSQL: configs.ServiceConfig.DbMaker.PostgreSQL.PlugIn,
+}
+
+// gasperServers holds the database servers used by Master which share their node with DbMaker
+var gasperServers = []string{types.MongoDBGasper, types.RedisGasper}
+
+// currentNode returns the URL of the DbMaker instance on the current node
+func currentNode() string {
+	return fmt.Sprintf("%s:%d", utils.HostIP, configs.ServiceConfig.DbMaker.Port)
+}
+
+// reconcileContainer restarts the stopped container of a database server or a Redis database
+// and recreates the container if it is missing or cannot be restarted
+func reconcileContainer(report *types.ReconcileReport, name string, states map[string]string, recreate func(string) error) {
+	state, found := states[name]
+	switch {
+	case !found || state == "dead":
+		report.Recreated = append(report.Recreated, name)
+		if report.DryRun {
+			return
+		}
+		if err := recreate(name); err != nil {
+			report.Fail(name, err)
+		}
+	case state == "exited" || state == "created":
+		report.Restarted = append(report.Restarted, name)
+		if report.DryRun {
+			return
+		}
+		if err := docker.StartContainer(name); err != nil {
+			report.Fail(name, err)
+		}
+	}
+}
+
+// recreateServer deploys a database server whose container has gone missing on the current node again
+// The server's storage directory is mounted again hence the databases held by it are kept
+func recreateServer(language string) error {
+	// Errors are ignored as the container may not exist at all
+	docker.DeleteContainer(language)
+	if _, err := database.SetupDBInstance(language); err != nil {
+		return err
+	}
+	return nil
+}
+
+// recreateRedisDatabase deploys a Redis database whose container has gone missing on the current node again
+// The database's storage directory is mounted again hence its data is kept
+func recreateRedisDatabase(databaseName string) error {
+	db, err := mongo.FetchSingleDatabase(databaseName)
+	if err != nil {
+		return err
+	}
+
+	// Errors are ignored as the container may not exist at all
+	docker.DeleteContainer(databaseName)
+	if err := database.CreateRedisDB(db); err != nil {
+		return err
+	}
+
+	err = mongo.UpdateInstance(types.M{
+		mongo.NameKey:         databaseName,
+		mongo.InstanceTypeKey: mongo.DBInstance,
+	}, db)
+	if err != nil {
+		return err
+	}
+	return redis.RegisterDB(databaseName, currentNode(), fmt.Sprintf("%s:%d", utils.HostIP, db.GetContainerPort()))
+}
+
+// findOrphans returns the database containers and Redis storage directories on the current node
+// which don't belong to any of the given Redis databases or to a database server
+func findOrphans(report *types.ReconcileReport, redisNames []string) {
+	expectedContainers := make(map[string]bool)
+	expectedStorage := make(map[string]bool)
+	for server := range databaseServers {
+		expectedContainers[server] = true
+	}
+	for _, server := range gasperServers {
+		expectedContainers[server] = true
+	}
+	for _, name := range redisNames {
+		expectedContainers[name] = true
+		expectedStorage[filepath.Join(database.RedisStorageRoot(), name)] = true
+	}
+
+	containers, err := docker.ListLabelledContainers(docker.DatabaseLabel)
+	if err != nil {
+		report.Fail(ServiceName, err)
+	}
+	for containerName, createdAt := range containers {
+		if !expectedContainers[containerName] && time.Since(createdAt) > orphanGracePeriod {
+			report.OrphanContainers = append(report.OrphanContainers, containerName)
+		}
+	}
+
+	entries, err := ioutil.ReadDir(database.RedisStorageRoot())
+	if err != nil && !os.IsNotExist(err) {
+		report.Fail(ServiceName, err)
+	}
+	for _, entry := range entries {
+		storedir := filepath.Join(database.RedisStorageRoot(), entry.Name())
+		if entry.IsDir() && !expectedStorage[storedir] && time.Since(entry.ModTime()) > orphanGracePeriod {
+			report.OrphanStorage = append(report.OrphanStorage, storedir)
+		}
+	}
+}
+
+// reconcile compares the database servers and Redis databases of the current node with the containers
+// and storage directories present on it
+// Stopped containers are restarted and missing ones are recreated, orphaned containers and
+// storage directories are removed only if garbage collection is enabled
+func reconcile(dryRun bool) *types.ReconcileReport {
+	if !dryRun {
+		reconcileMutex.Lock()
+		defer reconcileMutex.Unlock()
+	}
+
+	report := types.NewReconcileReport(utils.HostIP, ServiceName, dryRun)
+
+	states, err := docker.ListContainerStates()
+	if err != nil {
+		report.Fail(ServiceName, err)
+		return report
+	}
+
+	// Databases other than Redis live inside the database servers and have no containers of their own
+	redisNames, err := mongo.FetchNames(mongo.InstanceCollection, types.M{
+		mongo.HostIPKey:       utils.HostIP,
+		mongo.InstanceTypeKey: mongo.DBInstance,
+		mongo.LanguageKey:     types.Redis,
+	})
+	if err != nil {
+		report.Fail(ServiceName, err)
+		return report
+	}
+
+	for server, deployed := range databaseServers {
+		if deployed {
+			reconcileContainer(report, server, states, recreateServer)
+		}
+	}
+	for _, name := range redisNames {
+		reconcileContainer(report, name, states, recreateRedisDatabase)
+	}
+
+	findOrphans(report, redisNames)
+	if report.DryRun || !configs.ServiceConfig.DbMaker.CollectGarbage {
+		return report
+	}
+	for _, containerName := range report.OrphanContainers {
+		if err := docker.DeleteContainer(containerName); err != nil {
+			report.Fail(containerName, err)
+		}
+	}
+	for _, storedir := range report.OrphanStorage {
+		if err := os.RemoveAll(storedir); err != nil {
+			report.Fail(storedir, err)
+		}
+	}
+	report.Collected = true
+	return report
+}
+
+// logReconcileReport logs the actions taken by a reconciliation
+func logReconcileReport(report *types.ReconcileReport) {
+	for _, name := range report.Restarted {
+		utils.LogInfo("DbMaker-Reconciler-1", "Restarted the stopped container %s", name)
+	}
+	for _, name := range report.Recreated {
+		utils.LogInfo("DbMaker-Reconciler-2", "Recreated the missing container %s", name)
+	}
+	if !report.Collected && len(report.OrphanContainers)+len(report.OrphanStorage) > 0 {
+		utils.LogInfo("DbMaker-Reconciler-3", "Found orphaned containers %v and storage directories %v",
+			report.OrphanContainers, report.OrphanStorage)
+	}
+	for name, err := range report.Errors {
+		utils.LogError("DbMaker-Reconciler-4", fmt.Errorf("Failed to reconcile %s: %s", name, err))
+	}
+}
+
+// Reconcile reconciles the database containers of the current node with the databases stored for it
+func (s *server) Reconcile(ctx context.Context, body *pb.ReconcileRequest) (*pb.ResponseBody, error) {
+	report := reconcile(body.GetDryRun())
+	if !report.DryRun {
+		logReconcileReport(report)
+	}
+	response, err := json.Marshal(report)
+	return &pb.ResponseBody{Data: response}, err
+}
+
+// ScheduleReconciliation runs the reconciler at the given reconcile interval
+func ScheduleReconciliation() {
+	interval := configs.ServiceConfig.DbMaker.ReconcileInterval * time.Second
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	scheduler := utils.NewScheduler(interval, func() {
+		logReconcileReport(reconcile(false))
+	})
+	scheduler.RunAsync()
+}
@@ -0,0 +1,69 @@
+package gendns
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// resubscribeInterval is the time after which the subscription to instance events is renewed if it ends
+const resubscribeInterval = 5 * time.Second
+
+// proxyAddresses holds the sorted IPv4 addresses of the GenProxy instances as of the last update of the storage
+var proxyAddresses atomic.Value
+
+// pickProxyAddress picks the address of a GenProxy instance for an application registered between
+// two updates of the storage, the next update balances the records among the instances again
+func pickProxyAddress(name string) (string, bool) {
+	addresses, _ := proxyAddresses.Load().([]string)
+	if len(addresses) == 0 {
+		return "", false
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return addresses[hash.Sum32()%uint32(len(addresses))], true
+}
+
+// applyInstanceEvent applies a change to the bindings of an application or a database to the storage right away
+// instead of waiting for its next periodic update
+func applyInstanceEvent(event *types.InstanceEvent) {
+	switch event.Key {
+	case redis.ApplicationKey:
+		fqdn := fmt.Sprintf("%s.app.%s.", event.Name, configs.GasperConfig.Domain)
+		if event.Removed {
+			storage.Delete(fqdn)
+			return
+		}
+		// Records of registered applications are kept as they are to avoid moving them between GenProxy instances
+		if _, found := storage.Get(fqdn); found {
+			return
+		}
+		if address, found := pickProxyAddress(event.Name); found {
+			storage.Set(fqdn, address)
+		}
+	case redis.DatabaseKey:
+		fqdn := fmt.Sprintf("%s.db.%s.", event.Name, configs.GasperConfig.Domain)
+		if event.Removed {
+			storage.Delete(fqdn)
+			return
+		}
+		if event.Bindings != nil && strings.Contains(event.Bindings.Server, ":") {
+			storage.Set(fqdn, strings.Split(event.Bindings.Server, ":")[0])
+		}
+	}
+}
+
+// SubscribeUpdates applies the changes to the bindings of applications and databases published through Redis
+// as they happen, the periodic updates of the storage remain in place for the changes missed while unsubscribed
+func SubscribeUpdates() {
+	for {
+		redis.SubscribeInstanceEvents(applyInstanceEvent)
+		time.Sleep(resubscribeInterval)
+	}
+}
@@ -53,6 +53,12 @@ func updateStorage() {
 	updateBody := make(map[string]string)
 	instanceNum := len(reverseProxyInstances)
 
+	addresses := make([]string, 0, instanceNum)
+	for _, instance := range reverseProxyInstances {
+		addresses = append(addresses, strings.Split(instance, ":")[0])
+	}
+	proxyAddresses.Store(addresses)
+
 	// Create enrties for applications
 	appMap, err := redis.FetchAllApps()
 	if err != nil {
@@ -68,6 +74,20 @@ func updateStorage() {
 		updateBody[fqdn] = address
 	}
 
+	// Create entries for the verified custom domains of applications
+	domainMap, err := redis.FetchAllDomains()
+	if err != nil {
+		handleError(err)
+		return
+	}
+	domains := utils.GetMapKeys(domainMap)
+	sort.Strings(domains)
+
+	for index, domain := range domains {
+		address := strings.Split(reverseProxyInstances[index%instanceNum], ":")[0]
+		updateBody[domain+"."] = address
+	}
+
 	// Create enrties for databases
 	dbMap, err := redis.FetchAllDatabases()
 	if err != nil {
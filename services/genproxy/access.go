@@ -0,0 +1,131 @@
+package genproxy
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// accessPolicy is the access policy of an application with its IP lists parsed
+type accessPolicy struct {
+	rateLimit *types.RateLimit
+	allow     []*net.IPNet
+	deny      []*net.IPNet
+}
+
+var (
+	// accessPolicies holds the access policies of applications mapped to the applications
+	accessPolicies atomic.Value
+
+	// rejections holds the number of requests rejected by the access policies of applications since the
+	// last update of the record storage mapped to the applications and the reasons of rejection
+	rejections      = make(map[string]map[string]int64)
+	rejectionsMutex sync.Mutex
+)
+
+// updateAccessPolicies replaces the access policies of applications
+func updateAccessPolicies(policies map[string]*types.AccessPolicy) {
+	parsed := make(map[string]*accessPolicy, len(policies))
+	for name, policy := range policies {
+		allow, err := types.ParseCIDRs(policy.Allow)
+		if err != nil {
+			utils.LogError("GenProxy-Access-1", err)
+			continue
+		}
+		deny, err := types.ParseCIDRs(policy.Deny)
+		if err != nil {
+			utils.LogError("GenProxy-Access-2", err)
+			continue
+		}
+		parsed[name] = &accessPolicy{
+			rateLimit: policy.RateLimit,
+			allow:     allow,
+			deny:      deny,
+		}
+	}
+	accessPolicies.Store(parsed)
+}
+
+// recordRejection records a request to an application rejected by its access policy
+func recordRejection(name, reason string) {
+	rejectionsMutex.Lock()
+	if rejections[name] == nil {
+		rejections[name] = make(map[string]int64)
+	}
+	rejections[name][reason]++
+	rejectionsMutex.Unlock()
+}
+
+// flushRejections adds the rejections recorded since the last flush to the counters in Redis
+func flushRejections() {
+	rejectionsMutex.Lock()
+	recorded := rejections
+	rejections = make(map[string]map[string]int64)
+	rejectionsMutex.Unlock()
+
+	if err := redis.RecordAccessRejections(recorded); err != nil {
+		utils.LogError("GenProxy-Access-3", err)
+	}
+}
+
+// containsIP checks whether an IP address falls in any of the networks
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// enforceAccessPolicy rejects the requests to an application from the client IPs which aren't permitted by
+// its IP lists or have exceeded its rate limit, it returns false if the request has been rejected
+// The client IP is the address of the connection since headers such as X-Forwarded-For can be forged
+func enforceAccessPolicy(c *gin.Context, name string) bool {
+	policies, _ := accessPolicies.Load().(map[string]*accessPolicy)
+	policy, found := policies[name]
+	if !found {
+		return true
+	}
+	clientIP := hostname(c.Request.RemoteAddr)
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return true
+	}
+
+	if containsIP(policy.deny, ip) || (len(policy.allow) > 0 && !containsIP(policy.allow, ip)) {
+		recordRejection(name, types.AccessDenied)
+		c.AbortWithStatusJSON(403, gin.H{
+			"success": false,
+			"message": fmt.Sprintf("Requests from %s to application %s are not permitted", clientIP, name),
+		})
+		return false
+	}
+
+	if policy.rateLimit == nil {
+		return true
+	}
+	allowed, err := redis.TakeRateLimitToken(name, ip.String(), policy.rateLimit)
+	if err != nil {
+		// Requests are let through if the rate limit cannot be checked so that applications stay reachable
+		utils.LogError("GenProxy-Access-4", err)
+		return true
+	}
+	if !allowed {
+		recordRejection(name, types.AccessRateLimited)
+		c.Header("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(1/policy.rateLimit.Rate()))))
+		c.AbortWithStatusJSON(429, gin.H{
+			"success": false,
+			"message": fmt.Sprintf("Too many requests to application %s", name),
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,382 @@
+package genproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/cloudflare"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	// acmeChallengePath is the path under which GenProxy answers ACME HTTP-01 challenges
+	acmeChallengePath = "/.well-known/acme-challenge/"
+
+	// certificateCheckInterval is the time interval between consecutive checks for certificates to be obtained
+	certificateCheckInterval = 5 * time.Minute
+
+	// certificateLockTTL is the time after which the lock on the issuance of a certificate expires
+	certificateLockTTL = 10 * time.Minute
+
+	// issuanceTimeout is the maximum time taken for obtaining a single certificate
+	issuanceTimeout = 5 * time.Minute
+
+	// defaultRenewBefore is the time before expiry at which certificates are renewed when none is configured
+	defaultRenewBefore = 30 * 24 * time.Hour
+
+	// issuanceBackoff is the delay before the second attempt of a failed issuance which doubles with every attempt
+	issuanceBackoff = 5 * time.Minute
+
+	// maxIssuanceBackoff is the maximum delay between two attempts of a failed issuance
+	maxIssuanceBackoff = 24 * time.Hour
+)
+
+var (
+	// registeredClient is the ACME client registered with the account shared by all GenProxy instances
+	registeredClient *acme.Client
+	clientMutex      sync.Mutex
+)
+
+// issuanceDelay returns the delay before the next attempt of an issuance which has failed the given number of times
+func issuanceDelay(attempts int) time.Duration {
+	delay := issuanceBackoff
+	for i := 1; i < attempts && delay < maxIssuanceBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxIssuanceBackoff {
+		return maxIssuanceBackoff
+	}
+	return delay
+}
+
+// renewBefore returns the time before expiry at which certificates are renewed
+func renewBefore() time.Duration {
+	days := configs.ServiceConfig.GenProxy.SSL.ACME.RenewBefore
+	if days <= 0 {
+		return defaultRenewBefore
+	}
+	return days * 24 * time.Hour
+}
+
+// dnsChallengeRecord returns the TXT record holding the DNS-01 challenge of a hostname
+// The record `_acme-challenge.<hostname>` must be a CNAME pointing to it
+func dnsChallengeRecord(hostname string) string {
+	return fmt.Sprintf("%s.%s.%s", hostname, cloudflare.ACMEChallenge, configs.GasperConfig.Domain)
+}
+
+// accountKey returns the private key of the ACME account shared by all GenProxy instances
+// A new key is generated and stored if there is none
+func accountKey() (crypto.Signer, error) {
+	encrypted, err := redis.FetchACMEAccount()
+	if err != nil {
+		return nil, err
+	}
+	if encrypted == "" {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		encrypted, err = utils.Encrypt(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+		if err != nil {
+			return nil, err
+		}
+		// Another instance may have stored its key in the meantime
+		if encrypted, err = redis.RegisterACMEAccount(encrypted); err != nil {
+			return nil, err
+		}
+	}
+
+	keyPEM, err := utils.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("Invalid private key of the ACME account")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// acmeHTTPClient returns the HTTP client for the ACME directory which trusts the configured CA certificate
+func acmeHTTPClient() (*http.Client, error) {
+	caCertificate := configs.ServiceConfig.GenProxy.SSL.ACME.CACertificate
+	if caCertificate == "" {
+		return http.DefaultClient, nil
+	}
+	caPEM, err := ioutil.ReadFile(caCertificate)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("No certificates found in %s", caCertificate)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}, nil
+}
+
+// acmeClient returns the ACME client registered with the account shared by all GenProxy instances
+func acmeClient(ctx context.Context) (*acme.Client, error) {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	if registeredClient != nil {
+		return registeredClient, nil
+	}
+
+	key, err := accountKey()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := acmeHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	newClient := &acme.Client{
+		Key:          key,
+		DirectoryURL: configs.ServiceConfig.GenProxy.SSL.ACME.Directory,
+		HTTPClient:   httpClient,
+	}
+
+	account := &acme.Account{}
+	if email := configs.ServiceConfig.GenProxy.SSL.ACME.Email; email != "" {
+		account.Contact = []string{"mailto:" + email}
+	}
+	if _, err := newClient.Register(ctx, account, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return nil, err
+	}
+	registeredClient = newClient
+	return registeredClient, nil
+}
+
+// completeChallenge proves the control of the hostname of an authorization through the configured challenge
+func completeChallenge(ctx context.Context, client *acme.Client, authz *acme.Authorization) error {
+	challengeType := "http-01"
+	if configs.ServiceConfig.GenProxy.SSL.ACME.Challenge == types.DNSChallenge {
+		challengeType = "dns-01"
+	}
+	var challenge *acme.Challenge
+	for _, offered := range authz.Challenges {
+		if offered.Type == challengeType {
+			challenge = offered
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("ACME server offers no %s challenge for %s", challengeType, authz.Identifier.Value)
+	}
+
+	if challengeType == "dns-01" {
+		value, err := client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return err
+		}
+		record, err := cloudflare.CreateChallengeRecord(dnsChallengeRecord(authz.Identifier.Value), value)
+		if err != nil {
+			return err
+		}
+		defer cloudflare.DeleteChallengeRecord(record.Result.ID)
+	} else {
+		keyAuth, err := client.HTTP01ChallengeResponse(challenge.Token)
+		if err != nil {
+			return err
+		}
+		if err := redis.RegisterACMEChallenge(challenge.Token, keyAuth); err != nil {
+			return err
+		}
+		defer redis.RemoveACMEChallenge(challenge.Token)
+	}
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return err
+	}
+	_, err := client.WaitAuthorization(ctx, authz.URI)
+	return err
+}
+
+// obtainCertificate obtains a certificate for a hostname over ACME and stores it for all GenProxy instances
+func obtainCertificate(ctx context.Context, hostname string) error {
+	client, err := acmeClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, []acme.AuthzID{{Type: "dns", Value: hostname}})
+	if err != nil {
+		return err
+	}
+	for _, authzURL := range order.AuthzURLs {
+		authz, err := client.GetAuthorization(ctx, authzURL)
+		if err != nil {
+			return err
+		}
+		if authz.Status == acme.StatusValid {
+			continue
+		}
+		if err := completeChallenge(ctx, client, authz); err != nil {
+			return err
+		}
+	}
+	if order, err = client.WaitOrder(ctx, order.URI); err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{hostname}}, key)
+	if err != nil {
+		return err
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return err
+	}
+
+	var certificatePEM bytes.Buffer
+	for _, der := range chain {
+		if err := pem.Encode(&certificatePEM, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			return err
+		}
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	privateKey, err := utils.Encrypt(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	if err != nil {
+		return err
+	}
+
+	return mongo.UpsertCertificate(types.M{mongo.HostnameKey: hostname}, &types.Certificate{
+		Hostname:    hostname,
+		Certificate: certificatePEM.String(),
+		PrivateKey:  privateKey,
+		NotAfter:    leaf.NotAfter.Unix(),
+		IssuedAt:    time.Now().Unix(),
+	})
+}
+
+// issueCertificate obtains the certificate of a hostname unless another GenProxy instance is already doing so
+// Failures are stored along with the time of the next attempt so that all instances back off
+func issueCertificate(hostname string, previous *types.Certificate) {
+	acquired, err := redis.AcquireCertificateLock(hostname, utils.HostIP, certificateLockTTL)
+	if err != nil {
+		utils.LogError("GenProxy-ACME-1", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer redis.ReleaseCertificateLock(hostname)
+
+	ctx, cancel := context.WithTimeout(context.Background(), issuanceTimeout)
+	defer cancel()
+	if err := obtainCertificate(ctx, hostname); err != nil {
+		attempts := 1
+		if previous != nil {
+			attempts = previous.Attempts + 1
+		}
+		utils.LogError("GenProxy-ACME-2", fmt.Errorf("Failed to obtain a certificate for %s: %s", hostname, err))
+		err = mongo.UpsertCertificate(types.M{mongo.HostnameKey: hostname}, types.M{
+			mongo.HostnameKey: hostname,
+			"attempts":        attempts,
+			"last_error":      err.Error(),
+			"next_attempt_at": time.Now().Add(issuanceDelay(attempts)).Unix(),
+		})
+		if err != nil {
+			utils.LogError("GenProxy-ACME-3", err)
+		}
+		return
+	}
+	utils.LogInfo("GenProxy-ACME-4", "Obtained a certificate for %s", hostname)
+}
+
+// renewCertificates obtains the certificates of verified custom domains which have none or whose
+// certificates are about to expire, the certificates of detached domains are removed
+func renewCertificates() {
+	domains, err := redis.FetchAllDomains()
+	if err != nil {
+		utils.LogError("GenProxy-ACME-5", err)
+		return
+	}
+	stored, err := mongo.FetchCertificates(types.M{})
+	if err != nil {
+		utils.LogError("GenProxy-ACME-6", err)
+		return
+	}
+
+	existing := make(map[string]*types.Certificate)
+	for _, certificate := range stored {
+		if _, found := domains[certificate.Hostname]; !found {
+			if _, err := mongo.DeleteCertificate(types.M{mongo.HostnameKey: certificate.Hostname}); err != nil {
+				utils.LogError("GenProxy-ACME-7", err)
+			}
+			continue
+		}
+		existing[certificate.Hostname] = certificate
+	}
+
+	now := time.Now()
+	for hostname := range domains {
+		certificate := existing[hostname]
+		if certificate != nil {
+			if certificate.NotAfter > 0 && now.Add(renewBefore()).Unix() < certificate.NotAfter {
+				continue
+			}
+			if certificate.NextAttemptAt > now.Unix() {
+				continue
+			}
+		}
+		issueCertificate(hostname, certificate)
+	}
+	loadCertificates()
+}
+
+// serveACMEChallenge answers a pending ACME HTTP-01 challenge with its key authorization
+// False is returned if there is no such challenge so that the request is proxied as usual
+func serveACMEChallenge(c *gin.Context) bool {
+	token := strings.TrimPrefix(c.Request.URL.Path, acmeChallengePath)
+	keyAuth, err := redis.FetchACMEChallenge(token)
+	if err != nil {
+		return false
+	}
+	c.String(200, keyAuth)
+	return true
+}
+
+// ScheduleCertificateRenewal periodically obtains and renews the certificates of verified custom domains over ACME
+func ScheduleCertificateRenewal() {
+	scheduler := utils.NewScheduler(certificateCheckInterval, renewCertificates)
+	scheduler.RunAsync()
+}
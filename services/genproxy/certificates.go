@@ -0,0 +1,81 @@
+package genproxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+var (
+	// certificates holds the certificates obtained over ACME mapped to their hostnames
+	certificates atomic.Value
+
+	// defaultCertificate is the certificate from the configuration served for the hostnames
+	// without a certificate obtained over ACME
+	defaultCertificate *tls.Certificate
+)
+
+// loadCertificates loads the certificates obtained over ACME by all GenProxy instances
+func loadCertificates() {
+	stored, err := mongo.FetchCertificates(types.M{
+		"certificate": types.M{"$exists": true},
+	})
+	if err != nil {
+		utils.LogError("GenProxy-Certificates-1", err)
+		return
+	}
+
+	loaded := make(map[string]*tls.Certificate)
+	for _, certificate := range stored {
+		privateKey, err := utils.Decrypt(certificate.PrivateKey)
+		if err != nil {
+			utils.LogError("GenProxy-Certificates-2", err)
+			continue
+		}
+		keyPair, err := tls.X509KeyPair([]byte(certificate.Certificate), privateKey)
+		if err != nil {
+			utils.LogError("GenProxy-Certificates-3", err)
+			continue
+		}
+		loaded[certificate.Hostname] = &keyPair
+	}
+	certificates.Store(loaded)
+}
+
+// getCertificate returns the certificate of the hostname requested over SNI
+func getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	hostname := strings.TrimSuffix(strings.ToLower(hello.ServerName), ".")
+	loaded, _ := certificates.Load().(map[string]*tls.Certificate)
+	if certificate, found := loaded[hostname]; found {
+		return certificate, nil
+	}
+	if defaultCertificate != nil {
+		return defaultCertificate, nil
+	}
+	return nil, fmt.Errorf("No certificate is available for %s", hostname)
+}
+
+// NewTLSConfig returns the TLS configuration of GenProxy with SSL which picks the certificate per SNI hostname
+// The certificate and private key from the configuration are optional only if certificates are obtained over ACME
+func NewTLSConfig() (*tls.Config, error) {
+	ssl := configs.ServiceConfig.GenProxy.SSL
+	if ssl.Certificate != "" || ssl.PrivateKey != "" || !ssl.ACME.PlugIn {
+		keyPair, err := tls.LoadX509KeyPair(ssl.Certificate, ssl.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		defaultCertificate = &keyPair
+	}
+	if ssl.ACME.PlugIn {
+		loadCertificates()
+	}
+	return &tls.Config{
+		GetCertificate: getCertificate,
+	}, nil
+}
@@ -2,11 +2,15 @@ package genproxy
 
 import (
 	"fmt"
+	"html"
+	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/types"
 )
@@ -17,12 +21,26 @@ const (
 
 	// SSLServiceName is the name of the service proxying HTTPS connections
 	SSLServiceName = types.GenProxySSL
+
+	// stoppedPage is served in place of the applications which have been stopped by their owners
+	stoppedPage = `<!DOCTYPE html>
+<html>
+<head><title>Application Stopped</title></head>
+<body style="font-family: sans-serif; text-align: center; padding-top: 10%%">
+<h1>Application Stopped</h1>
+<p>The application <b>%s</b> has been stopped by its owner</p>
+</body>
+</html>
+`
 )
 
 var (
+	// outlierDetection defines when the upstreams are ejected from load balancing
+	outlierDetection = newOutlierDetection()
+
 	// storage stores the reverse proxy records in the form of Key : Value pairs
-	// with Application Name as the key and its URL(IP:Port) as the value
-	storage = types.NewProxyStorage()
+	// with Application Name as the key and the URLs(IP:Port) of its replicas as the value
+	storage = types.NewProxyStorage(outlierDetection)
 
 	// balancedInstances are the services for which GenProxy load balances the
 	// request among multiple instances
@@ -32,7 +50,13 @@ var (
 	}
 
 	// masterBalancer load balances requests among multiple master instances
-	masterBalancer = types.NewLoadBalancer()
+	masterBalancer = types.NewLoadBalancer(outlierDetection)
+
+	// customDomains holds the applications served on verified custom domains mapped to the domains
+	customDomains atomic.Value
+
+	// stoppedApps holds the set of applications which have been stopped by their owners
+	stoppedApps atomic.Value
 
 	// Root domain name for validating host names
 	rootDomain = fmt.Sprintf(".%s", configs.GasperConfig.Domain)
@@ -41,17 +65,94 @@ var (
 	rootDomainWithPort = fmt.Sprintf("%s:%d", rootDomain, configs.ServiceConfig.GenProxy.Port)
 )
 
-// reverseProxy sets up the reverse proxy from the given domain to the target IP
-func reverseProxy(c *gin.Context) {
-	if !strings.HasSuffix(c.Request.Host, rootDomain) && !strings.HasSuffix(c.Request.Host, rootDomainWithPort) {
-		c.AbortWithStatusJSON(403, gin.H{
+// hostname returns the hostname of a request without its port
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+	return host
+}
+
+// domainApp returns the application served on a verified custom domain
+func domainApp(hostname string) (string, bool) {
+	domains, _ := customDomains.Load().(map[string]string)
+	name, found := domains[hostname]
+	return name, found
+}
+
+// serveDomainChallenge answers the HTTP challenge of an unverified custom domain with its token
+func serveDomainChallenge(c *gin.Context, hostname string) {
+	token := strings.TrimPrefix(c.Request.URL.Path, types.DomainChallengePath)
+	expected, err := redis.FetchDomainChallenge(hostname)
+	if err != nil || token == "" || token != expected {
+		c.AbortWithStatusJSON(404, gin.H{
 			"success": false,
-			"message": "Incorrect root domain",
+			"message": "No such challenge exists",
 		})
 		return
 	}
+	c.String(200, expected)
+}
+
+// isStopped checks whether an application has been stopped by its owner
+func isStopped(name string) bool {
+	stopped, _ := stoppedApps.Load().(map[string]bool)
+	return stopped[name]
+}
+
+// reverseProxy sets up the reverse proxy from the given domain to the target IP
+func reverseProxy(c *gin.Context) {
+	if strings.HasPrefix(c.Request.URL.Path, acmeChallengePath) && serveACMEChallenge(c) {
+		return
+	}
+
+	// Routes on a hostname take precedence over the application owning it, otherwise applications are
+	// served on their custom domains by the exact hostname and on the subdomains of the root domain
+	// by the first label of the hostname
+	route, isRouted := matchRoute(hostname(c.Request.Host), c.Request.URL.Path)
+	name, isCustom := domainApp(hostname(c.Request.Host))
+	if isRouted {
+		name = route.Target
+	} else if !isCustom {
+		if !strings.HasSuffix(c.Request.Host, rootDomain) && !strings.HasSuffix(c.Request.Host, rootDomainWithPort) {
+			if strings.HasPrefix(c.Request.URL.Path, types.DomainChallengePath) {
+				serveDomainChallenge(c, hostname(c.Request.Host))
+				return
+			}
+			c.AbortWithStatusJSON(403, gin.H{
+				"success": false,
+				"message": "Incorrect root domain",
+			})
+			return
+		}
+		name = strings.Split(c.Request.Host, ".")[0]
+	}
+	if !utils.Contains(balancedInstances, name) {
+		c.Set(appContextKey, name)
+	}
+	if !enforceAccessPolicy(c, name) {
+		return
+	}
+	if !enforceHTTPSPolicy(c, name) {
+		return
+	}
+	if isStopped(name) {
+		c.Data(503, "text/html; charset=utf-8", []byte(fmt.Sprintf(stoppedPage, html.EscapeString(name))))
+		return
+	}
+
+	// Requests to a sleeping application are held till the application has been woken up
+	if isSleeping(name) {
+		if err := wakeApplication(name); err != nil {
+			utils.LogError("GenProxy-Controller-1", err)
+			c.AbortWithStatusJSON(503, gin.H{
+				"success": false,
+				"message": fmt.Sprintf("Application %s is waking up, try again in a few seconds", name),
+			})
+			return
+		}
+	}
 
-	name := strings.Split(c.Request.Host, ".")[0]
 	var proxy *types.ProxyInfo
 	var success bool
 
@@ -59,6 +160,9 @@ func reverseProxy(c *gin.Context) {
 		proxy, success = masterBalancer.Get()
 	} else {
 		proxy, success = storage.Get(name)
+		if success {
+			recordActivity(name)
+		}
 	}
 
 	if !success {
@@ -68,14 +172,17 @@ func reverseProxy(c *gin.Context) {
 		})
 		return
 	}
-	proxy.Serve(c)
+	if isRouted {
+		route.Rewrite(c.Request)
+	}
+	serveUpstream(c, name, proxy)
 }
 
 // NewService returns a new instance of the current microservice
 func NewService() http.Handler {
 	// router is the main routes handler for the current microservice package
 	router := gin.New()
-	router.Use(gin.Recovery())
+	router.Use(gin.Recovery(), logAccess)
 	router.NoRoute(reverseProxy)
 	return router
 }
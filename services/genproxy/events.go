@@ -0,0 +1,63 @@
+package genproxy
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/types"
+)
+
+// resubscribeInterval is the time after which the subscription to instance events is renewed if it ends
+const resubscribeInterval = 5 * time.Second
+
+// setMembership adds an application to or removes it from a set of applications held by an atomic value
+// The set is replaced instead of being modified as it is read without locks
+func setMembership(set *atomic.Value, name string, member bool) {
+	current, _ := set.Load().(map[string]bool)
+	if current[name] == member {
+		return
+	}
+	updated := make(map[string]bool, len(current)+1)
+	for app := range current {
+		if app != name {
+			updated[app] = true
+		}
+	}
+	if member {
+		updated[name] = true
+	}
+	set.Store(updated)
+}
+
+// applyInstanceEvent applies a change to the bindings of an application to the record storage right away
+// instead of waiting for its next periodic update
+func applyInstanceEvent(event *types.InstanceEvent) {
+	if event.Key != redis.ApplicationKey {
+		return
+	}
+	if event.Removed || event.Bindings == nil {
+		storage.Remove(event.Name)
+		setMembership(&stoppedApps, event.Name, false)
+		setMembership(&sleepingApps, event.Name, false)
+		return
+	}
+	if event.Bindings.Stopped {
+		storage.Remove(event.Name)
+		setMembership(&stoppedApps, event.Name, true)
+		setMembership(&sleepingApps, event.Name, false)
+		return
+	}
+	setMembership(&stoppedApps, event.Name, false)
+	setMembership(&sleepingApps, event.Name, event.Bindings.Sleeping)
+	storage.Set(event.Name, event.Bindings.Servers())
+}
+
+// SubscribeUpdates applies the changes to the bindings of applications published through Redis as they happen
+// The periodic updates of the record storage remain in place for the changes missed while unsubscribed
+func SubscribeUpdates() {
+	for {
+		redis.SubscribeInstanceEvents(applyInstanceEvent)
+		time.Sleep(resubscribeInterval)
+	}
+}
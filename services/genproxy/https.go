@@ -0,0 +1,89 @@
+package genproxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/types"
+)
+
+// httpsPolicies holds the HTTPS policies set by the owners of applications mapped to the applications
+var httpsPolicies atomic.Value
+
+// defaultHTTPSPolicy returns the HTTPS policy from the configuration applied to the applications without one
+func defaultHTTPSPolicy() *types.HTTPSPolicy {
+	ssl := configs.ServiceConfig.GenProxy.SSL
+	policy := &types.HTTPSPolicy{
+		Policy: ssl.HTTPSPolicy,
+		HSTS:   &ssl.HSTS,
+	}
+	if policy.Policy == "" {
+		policy.Policy = types.HTTPAllowed
+	}
+	return policy
+}
+
+// httpsPolicy returns the HTTPS policy of an application
+// Applications which set no HSTS header of their own inherit the one from the configuration
+func httpsPolicy(name string) *types.HTTPSPolicy {
+	policies, _ := httpsPolicies.Load().(map[string]*types.HTTPSPolicy)
+	policy, found := policies[name]
+	if !found {
+		return defaultHTTPSPolicy()
+	}
+	if policy.HSTS == nil {
+		return &types.HTTPSPolicy{
+			Policy: policy.Policy,
+			HSTS:   defaultHTTPSPolicy().HSTS,
+		}
+	}
+	return policy
+}
+
+// httpsURL returns the URL of a request made over HTTP on GenProxy with SSL
+func httpsURL(c *gin.Context) string {
+	host := hostname(c.Request.Host)
+	if port := configs.ServiceConfig.GenProxy.SSL.Port; port != 443 {
+		host = fmt.Sprintf("%s:%d", host, port)
+	}
+	return fmt.Sprintf("https://%s%s", host, c.Request.URL.RequestURI())
+}
+
+// enforceHTTPSPolicy redirects or rejects the HTTP requests to an application as per its HTTPS policy
+// and sets the HSTS header on its HTTPS responses, it returns false if the request has been answered
+func enforceHTTPSPolicy(c *gin.Context, name string) bool {
+	if !configs.ServiceConfig.GenProxy.SSL.PlugIn {
+		return true
+	}
+	policy := httpsPolicy(name)
+
+	// Browsers ignore the Strict-Transport-Security header in responses sent over HTTP
+	if c.Request.TLS != nil {
+		if header := policy.HSTS.Header(); header != "" {
+			c.Header("Strict-Transport-Security", header)
+		}
+		return true
+	}
+
+	switch policy.Policy {
+	case types.HTTPRedirect:
+		// Methods other than GET and HEAD are redirected with 308 so that clients retain the method and body
+		status := http.StatusPermanentRedirect
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+		c.Redirect(status, httpsURL(c))
+		c.Abort()
+		return false
+	case types.HTTPSOnly:
+		c.AbortWithStatusJSON(403, gin.H{
+			"success": false,
+			"message": fmt.Sprintf("Application %s is served only over HTTPS", name),
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package genproxy
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/sdslabs/gasper/types"
+)
+
+// routes holds the path based routes of applications mapped to their hostnames
+// The routes of a hostname are sorted by the length of their path prefix in descending order
+var routes atomic.Value
+
+// updateRoutes replaces the path based routes of applications
+func updateRoutes(list []*types.Route) {
+	table := make(map[string][]*types.Route)
+	for _, route := range list {
+		table[route.Hostname] = append(table[route.Hostname], route)
+	}
+	for _, hostRoutes := range table {
+		sort.Slice(hostRoutes, func(i, j int) bool {
+			return len(hostRoutes[i].Path) > len(hostRoutes[j].Path)
+		})
+	}
+	routes.Store(table)
+}
+
+// matchRoute returns the route with the longest path prefix matching a request on a hostname
+func matchRoute(hostname, path string) (*types.Route, bool) {
+	table, _ := routes.Load().(map[string][]*types.Route)
+	for _, route := range table[hostname] {
+		if route.Matches(path) {
+			return route, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,137 @@
+package genproxy
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// appContextKey is the key of the request context holding the application a request was made to
+	appContextKey = "app"
+
+	// upstreamContextKey is the key of the request context holding the address to which a request was proxied
+	upstreamContextKey = "upstream"
+
+	// trafficFlushInterval is the time interval between consecutive flushes of the traffic aggregates to MongoDB
+	trafficFlushInterval = 30 * time.Second
+)
+
+var (
+	// accessLogger writes the access logs of the requests to applications
+	accessLogger     *log.Logger
+	accessLoggerOnce sync.Once
+
+	// traffic holds the aggregates of the requests to applications mapped to the minute in which
+	// they were made and the applications
+	traffic      = make(map[int64]map[string]*types.Traffic)
+	trafficMutex sync.Mutex
+)
+
+// getAccessLogger returns the logger writing the access logs to the file from the configuration
+// The logs are written to the standard output if no file is configured or it cannot be opened
+func getAccessLogger() *log.Logger {
+	accessLoggerOnce.Do(func() {
+		accessLogger = log.New(os.Stdout, "", 0)
+		path := configs.ServiceConfig.GenProxy.AccessLog
+		if path == "" {
+			return
+		}
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			utils.LogError("GenProxy-Traffic-1", err)
+			return
+		}
+		accessLogger = log.New(file, "", 0)
+	})
+	return accessLogger
+}
+
+// observeTraffic adds a request to the aggregates of the application for the minute in which it was made
+func observeTraffic(name string, start time.Time, status, bytes int, latency float64) {
+	minute := start.Truncate(time.Minute).Unix()
+	trafficMutex.Lock()
+	defer trafficMutex.Unlock()
+	if traffic[minute] == nil {
+		traffic[minute] = make(map[string]*types.Traffic)
+	}
+	if traffic[minute][name] == nil {
+		traffic[minute][name] = types.NewTraffic(name, minute)
+	}
+	traffic[minute][name].Observe(status, bytes, latency)
+}
+
+// flushTraffic stores the aggregates of the minutes which have passed in MongoDB
+// Aggregates are stored per GenProxy instance and merged while being fetched
+func flushTraffic() {
+	current := time.Now().Truncate(time.Minute).Unix()
+	records := make([]interface{}, 0)
+	trafficMutex.Lock()
+	for minute, apps := range traffic {
+		if minute >= current {
+			continue
+		}
+		for _, record := range apps {
+			record.HostIP = utils.HostIP
+			records = append(records, record)
+		}
+		delete(traffic, minute)
+	}
+	trafficMutex.Unlock()
+
+	if len(records) == 0 {
+		return
+	}
+	if _, err := mongo.BulkRegisterTraffic(records); err != nil {
+		utils.LogError("GenProxy-Traffic-2", err)
+	}
+}
+
+// logAccess emits the access log of every request made to an application and adds it to the traffic aggregates
+// of the application, the handlers mark such requests with the application and the upstream they were proxied to
+func logAccess(c *gin.Context) {
+	start := time.Now()
+	// Routes can rewrite the path of the request before it is proxied
+	path := c.Request.URL.Path
+	c.Next()
+
+	name := c.GetString(appContextKey)
+	if name == "" {
+		return
+	}
+	latency := float64(time.Since(start).Microseconds()) / 1000
+	bytes := c.Writer.Size()
+	if bytes < 0 {
+		bytes = 0
+	}
+	entry := &types.AccessLog{
+		Time:     start.UTC().Format(time.RFC3339Nano),
+		App:      name,
+		Method:   c.Request.Method,
+		Host:     c.Request.Host,
+		Path:     path,
+		Status:   c.Writer.Status(),
+		Bytes:    bytes,
+		Latency:  latency,
+		ClientIP: hostname(c.Request.RemoteAddr),
+		Upstream: c.GetString(upstreamContextKey),
+	}
+	if entryJSON, err := json.Marshal(entry); err == nil {
+		getAccessLogger().Println(string(entryJSON))
+	}
+	observeTraffic(name, start, entry.Status, bytes, latency)
+}
+
+// ScheduleTrafficFlush periodically stores the traffic aggregates of the past minutes in MongoDB
+func ScheduleTrafficFlush() {
+	scheduler := utils.NewScheduler(trafficFlushInterval, flushTraffic)
+	scheduler.RunAsync()
+}
@@ -39,18 +39,62 @@ func updateStorage() {
 		return
 	}
 
-	updateBody := make(map[string]string)
-	appInfoStruct := &types.InstanceBindings{}
+	updateBody := make(map[string][]string)
+	stopped := make(map[string]bool)
+	sleeping := make(map[string]bool)
 
-	// Create entries for applications
+	// Create entries for applications along with their replicas
 	for name, data := range apps {
+		appInfoStruct := &types.InstanceBindings{}
 		resultByte := []byte(data)
 		if err = json.Unmarshal(resultByte, appInfoStruct); err != nil {
 			handleError(err)
 			continue
 		}
-		updateBody[name] = appInfoStruct.Server
+		if appInfoStruct.Stopped {
+			stopped[name] = true
+			continue
+		}
+		// Sleeping applications keep their entries so that they can be served once woken up
+		if appInfoStruct.Sleeping {
+			sleeping[name] = true
+		}
+		updateBody[name] = appInfoStruct.Servers()
+	}
+	stoppedApps.Store(stopped)
+	sleepingApps.Store(sleeping)
+	flushActivity()
+
+	// Custom domains are served on the exact hostname hence they are kept apart from the record storage
+	domains, err := redis.FetchAllDomains()
+	if err != nil {
+		handleError(err)
+	} else {
+		customDomains.Store(domains)
+	}
+
+	// Routes are matched by the hostname and path prefix before the record storage is looked up
+	routeList, err := redis.FetchAllRoutes()
+	if err != nil {
+		handleError(err)
+	} else {
+		updateRoutes(routeList)
+	}
+
+	policies, err := redis.FetchHTTPSPolicies()
+	if err != nil {
+		handleError(err)
+	} else {
+		httpsPolicies.Store(policies)
+	}
+
+	accessPolicyMap, err := redis.FetchAccessPolicies()
+	if err != nil {
+		handleError(err)
+	} else {
+		updateAccessPolicies(accessPolicyMap)
 	}
+	flushRejections()
 
 	// Create enrties for Master in the load balancer
 	masterInstances, err := redis.FetchServiceInstances(types.Master)
@@ -0,0 +1,140 @@
+package genproxy
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// defaultHealthCheckInterval is the time interval between consecutive active health checks
+	// of the upstreams when no interval is configured
+	defaultHealthCheckInterval = 10 * time.Second
+
+	// defaultMaxFailures is the number of consecutive failures after which an upstream is ejected
+	// when no number is configured
+	defaultMaxFailures = 5
+
+	// defaultEjectionTime is the minimum time for which an upstream is ejected when no time is configured
+	defaultEjectionTime = 30 * time.Second
+
+	// healthCheckTimeout is the time within which an upstream must answer an active health check
+	healthCheckTimeout = 5 * time.Second
+
+	// maxProxyAttempts is the maximum number of upstreams an idempotent request is sent to
+	maxProxyAttempts = 3
+)
+
+// idempotentMethods are the HTTP methods whose requests can be retried on another upstream
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// newOutlierDetection returns the outlier detection of the upstreams from the configuration
+func newOutlierDetection() *types.OutlierDetection {
+	healthCheck := configs.ServiceConfig.GenProxy.HealthCheck
+	detection := &types.OutlierDetection{
+		MaxFailures:  healthCheck.MaxFailures,
+		EjectionTime: healthCheck.EjectionTime * time.Second,
+	}
+	if detection.MaxFailures <= 0 {
+		detection.MaxFailures = defaultMaxFailures
+	}
+	if detection.EjectionTime <= 0 {
+		detection.EjectionTime = defaultEjectionTime
+	}
+	return detection
+}
+
+// getUpstream returns an upstream of a service other than the excluded ones
+func getUpstream(name string, excluded map[*types.ProxyInfo]bool) (*types.ProxyInfo, bool) {
+	if utils.Contains(balancedInstances, name) {
+		return masterBalancer.GetExcluding(excluded)
+	}
+	return storage.GetExcluding(name, excluded)
+}
+
+// isRetryable checks whether a request can be sent to another upstream after failing to reach one
+// Only the requests of idempotent methods without a body are retried as the body has already been read
+func isRetryable(req *http.Request) bool {
+	return utils.Contains(idempotentMethods, req.Method) && req.ContentLength == 0 && len(req.TransferEncoding) == 0
+}
+
+// serveUpstream proxies a request to an upstream of a service and retries idempotent requests on
+// other upstreams of the service if the upstream cannot be reached
+func serveUpstream(c *gin.Context, name string, proxy *types.ProxyInfo) {
+	tried := make(map[*types.ProxyInfo]bool)
+	for attempt := 1; ; attempt++ {
+		tried[proxy] = true
+		c.Set(upstreamContextKey, proxy.Host())
+		err := proxy.Serve(c)
+		if err == nil || c.Writer.Written() || c.Request.Context().Err() != nil {
+			return
+		}
+		utils.LogError("GenProxy-Upstream-1", fmt.Errorf("Failed to proxy request for %s to %s: %s", name, proxy.Host(), err))
+		if attempt >= maxProxyAttempts || !isRetryable(c.Request) {
+			break
+		}
+		next, found := getUpstream(name, tried)
+		if !found {
+			break
+		}
+		proxy = next
+	}
+	c.AbortWithStatusJSON(502, gin.H{
+		"success": false,
+		"message": fmt.Sprintf("Service %s is not reachable at the moment", name),
+	})
+}
+
+// isUpstreamHealthy checks whether an upstream accepts connections and doesn't answer with a server error
+func isUpstreamHealthy(client *http.Client, host string) bool {
+	res, err := client.Get(fmt.Sprintf("http://%s/", host))
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+	return res.StatusCode < 500
+}
+
+// checkUpstreams actively checks the health of all upstreams known to GenProxy
+func checkUpstreams() {
+	client := &http.Client{
+		Timeout: healthCheckTimeout,
+		// Redirects are answers of a healthy upstream hence they aren't followed
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	upstreams := append(storage.List(), masterBalancer.List()...)
+	var wg sync.WaitGroup
+	for _, upstream := range upstreams {
+		wg.Add(1)
+		go func(upstream *types.ProxyInfo) {
+			defer wg.Done()
+			upstream.Probe(isUpstreamHealthy(client, upstream.Host()))
+		}(upstream)
+	}
+	wg.Wait()
+}
+
+// ScheduleHealthChecks periodically checks the health of all upstreams known to GenProxy
+func ScheduleHealthChecks() {
+	interval := configs.ServiceConfig.GenProxy.HealthCheck.Interval * time.Second
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	scheduler := utils.NewScheduler(interval, checkUpstreams)
+	scheduler.RunAsync()
+}
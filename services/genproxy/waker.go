@@ -0,0 +1,140 @@
+package genproxy
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+)
+
+const (
+	// defaultWakeTimeout is the time for which a request to a sleeping application waits for the
+	// application to be ready when no timeout is configured
+	defaultWakeTimeout = 25 * time.Second
+
+	// readinessInterval is the time interval between consecutive checks of a waking application's readiness
+	readinessInterval = time.Second
+)
+
+// wakeup is the attempt to wake a sleeping application shared by the requests waiting for it
+type wakeup struct {
+	done chan struct{}
+	err  error
+}
+
+var (
+	// sleepingApps holds the set of applications which have been put to sleep for receiving no requests
+	sleepingApps atomic.Value
+
+	// activity holds the time of the latest request to applications since the last update of the record storage
+	activity      = make(map[string]int64)
+	activityMutex sync.Mutex
+
+	// wakeups holds the attempts in progress to wake sleeping applications
+	wakeups      = make(map[string]*wakeup)
+	wakeupsMutex sync.Mutex
+)
+
+// isSleeping checks whether an application has been put to sleep for receiving no requests
+func isSleeping(name string) bool {
+	sleeping, _ := sleepingApps.Load().(map[string]bool)
+	return sleeping[name]
+}
+
+// recordActivity records a request to an application
+func recordActivity(name string) {
+	activityMutex.Lock()
+	activity[name] = time.Now().Unix()
+	activityMutex.Unlock()
+}
+
+// flushActivity stores the requests recorded since the last flush in Redis
+func flushActivity() {
+	activityMutex.Lock()
+	recorded := activity
+	activity = make(map[string]int64)
+	activityMutex.Unlock()
+
+	if err := redis.RecordAppActivity(recorded); err != nil {
+		utils.LogError("GenProxy-Waker-1", err)
+	}
+}
+
+// wakeTimeout returns the time for which a request to a sleeping application waits for the application
+func wakeTimeout() time.Duration {
+	timeout := configs.ServiceConfig.GenProxy.WakeTimeout * time.Second
+	if timeout <= 0 {
+		return defaultWakeTimeout
+	}
+	return timeout
+}
+
+// startApplication starts a sleeping application along with its replicas and waits till it is ready
+func startApplication(name string) error {
+	bindings, err := redis.FetchAppBindings(name)
+	if err != nil {
+		return err
+	}
+	if _, err := factory.StartApplication(name, bindings.Node); err != nil {
+		return err
+	}
+	for _, replica := range bindings.Replicas {
+		if _, err := factory.StartApplication(name, replica.Node); err != nil {
+			utils.LogError("GenProxy-Waker-2", err)
+		}
+	}
+	if err := redis.MarkAppSleeping(name, false); err != nil {
+		return err
+	}
+
+	// The request waking the application is recorded right away so that it isn't put to sleep
+	// again before the next update of the record storage
+	if err := redis.RecordAppActivity(map[string]int64{name: time.Now().Unix()}); err != nil {
+		utils.LogError("GenProxy-Waker-3", err)
+	}
+
+	deadline := time.Now().Add(wakeTimeout())
+	for !utils.IsHealthy(bindings.Server) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Application %s is not ready yet", name)
+		}
+		time.Sleep(readinessInterval)
+	}
+	return nil
+}
+
+// wakeApplication wakes a sleeping application and waits till it is ready
+// Concurrent requests to the same application wait for a single attempt to wake it
+func wakeApplication(name string) error {
+	wakeupsMutex.Lock()
+	attempt, found := wakeups[name]
+	if !found {
+		attempt = &wakeup{done: make(chan struct{})}
+		wakeups[name] = attempt
+		go func() {
+			attempt.err = startApplication(name)
+			if attempt.err == nil {
+				// The record storage still holds the application as sleeping till its next update
+				markAwake(name)
+			}
+			wakeupsMutex.Lock()
+			delete(wakeups, name)
+			wakeupsMutex.Unlock()
+			close(attempt.done)
+		}()
+	}
+	wakeupsMutex.Unlock()
+
+	<-attempt.done
+	return attempt.err
+}
+
+// markAwake removes an application from the set of sleeping applications
+func markAwake(name string) {
+	setMembership(&sleepingApps, name, false)
+}
@@ -1,131 +1,68 @@
 package master
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/sdslabs/gasper/configs"
-	"github.com/sdslabs/gasper/lib/factory"
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/types"
 )
 
-// rescheduleApplications re-deploys applications present on lost nodes to other least loaded nodes
-func rescheduleApplications(apps []types.M) {
-	if len(apps) == 0 {
-		return
-	}
-
-	// distributionArray stores the number of more apps each instance can hold
-	// scoreArray and URLArray stores the urls of the instances and the corresponding urls
-	var distributionArray, scoreArray []int
-	var URLArray []string
-
-	n := int64(len(apps))
-
-	// fetch n least loaded appmaker instances
-	instances, err := redis.GetLeastLoadedInstancesWithScores(redis.WorkerInstanceKey, n)
-	if err != nil {
-		utils.LogError("Master-Cleaner-1", err)
-		return
-	}
-
-	if len(instances) == 0 {
-		utils.LogError("Master-Cleaner-2", errors.New("No instances available for re-scheduling"))
-		return
+// removeLostReplicas removes the replicas of applications present on a lost node
+// so that requests are no longer proxied to them
+func removeLostReplicas(instance, instanceIP string) {
+	filter := types.M{
+		mongo.HostIPKey: instanceIP,
 	}
-
-	for _, instance := range instances {
-		scoreArray = append(scoreArray, int(instance.Score))
-		distributionArray = append(distributionArray, 0)
-		URLArray = append(URLArray, fmt.Sprintf("%v", instance.Member))
-	}
-
-	deployedApps := n
-	level := scoreArray[0]
-
-	// generate the distributionArray based the corresponding scores(loads)
-	for deployedApps > 0 {
-		for idx := range scoreArray {
-			if level < scoreArray[idx] {
-				level++
-				break
-			} else {
-				deployedApps--
-				scoreArray[idx]++
-				distributionArray[idx]++
-			}
-		}
-	}
-
-	var index int
-
-	// deploy the apps based on the distribution generated by the distributionArray
-	for _, app := range apps {
-		instanceURL := URLArray[index]
-		distributionArray[index]--
-		if distributionArray[index] <= 0 {
-			index++
-		}
-		dataBytes, err := json.Marshal(app)
-		if err != nil {
-			utils.LogError("Master-Cleaner-3", err)
-			continue
-		}
-		name, ok := app[mongo.NameKey].(string)
-		if !ok {
-			continue
-		}
-		language, ok := app[mongo.LanguageKey].(string)
+	for _, replica := range mongo.FetchReplicas(filter) {
+		name, ok := replica[mongo.NameKey].(string)
 		if !ok {
 			continue
 		}
-		owner, ok := app[mongo.OwnerKey].(string)
-		if !ok {
-			continue
+		utils.LogInfo("Master-Cleaner-9", "Removing replica of application %s from %s", name, instance)
+		if err := redis.RemoveAppReplica(name, instance); err != nil {
+			utils.LogError("Master-Cleaner-10", err)
 		}
-		utils.LogInfo("Master-Cleaner-4", "Re-scheduling application %s to %s", name, instanceURL)
-
-		// TODO :-
-		// 1. Shift the below function call to a goroutine worker pool i.e fixed number of goroutines
-		// (maybe equal to number of CPU logical cores) to avoid CPU overload and thrashing
-		// 2. Check for errors and if any, reschedule that application to a different instance
-		go factory.CreateApplication(language, owner, instanceURL, dataBytes)
+	}
+	if _, err := mongo.DeleteReplicas(filter); err != nil {
+		utils.LogError("Master-Cleaner-11", err)
 	}
 }
 
 // inspectInstance checks whether a given instance is alive or not and deletes that instance
-// if it is dead
+// if it is dead, an instance is dead if its node's heartbeat has expired, doesn't report it
+// or has reported it as unhealthy for several consecutive heartbeats
 func inspectInstance(service, instance string) {
-	// Handle GenDNS's health-check by sending a UDP probe instead of TCP
-	if service == types.GenDNS {
-		if !utils.IsGenDNSAlive(instance) {
-			if err := redis.RemoveServiceInstance(service, instance); err != nil {
-				utils.LogError("Master-Cleaner-5", err)
-			}
-		}
+	if !strings.Contains(instance, ":") {
+		utils.LogError("Master-Cleaner-7", fmt.Errorf("Instance %s is in invalid format", instance))
+		return
+	}
+	instanceIP := strings.Split(instance, ":")[0]
+	heartbeat, err := redis.FetchHeartbeat(instanceIP)
+	if err != nil {
+		// The instance is kept as its liveness cannot be determined
+		utils.LogError("Master-Cleaner-5", err)
 		return
 	}
-	if utils.NotAlive(instance) {
+	if heartbeat == nil || !heartbeat.Reports(service, instance) {
+		utils.LogInfo("Master-Cleaner-13", "Heartbeat of instance %s of service %s is missing or unhealthy", instance, service)
 		if err := redis.RemoveServiceInstance(service, instance); err != nil {
 			utils.LogError("Master-Cleaner-6", err)
 		}
 		// Re-schedule applications for AppMaker microservice
 		if service == types.AppMaker {
-			if !strings.Contains(instance, ":") {
-				utils.LogError("Master-Cleaner-7", fmt.Errorf("Instance %s is in invalid format", instance))
-				return
-			}
-			instanceIP := strings.Split(instance, ":")[0]
 			apps := mongo.FetchAppInfo(types.M{
 				mongo.HostIPKey: instanceIP,
 			})
-			go rescheduleApplications(apps)
+			enqueueApplications(apps, instanceIP)
+			go removeLostReplicas(instance, instanceIP)
+			if err := redis.RemoveNodeCapacity(instance); err != nil {
+				utils.LogError("Master-Cleaner-12", err)
+			}
 		}
 	}
 }
@@ -142,8 +79,12 @@ func removeDeadServiceInstances(service string) {
 }
 
 // removeDeadInstances removes all inactive instances in every service
+// Only the leader removes them so that applications are not rescheduled twice
 func removeDeadInstances() {
 	time.Sleep(5 * time.Second)
+	if !IsLeader() {
+		return
+	}
 	for service := range configs.ServiceMap {
 		go removeDeadServiceInstances(service)
 	}
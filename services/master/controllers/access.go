@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// SetAccessPolicy sets the rate limit and IP lists which GenProxy enforces on the requests to an application
+func SetAccessPolicy(c *gin.Context) {
+	appName := c.Param("app")
+	policy := &types.AccessPolicy{}
+	if err := c.ShouldBindJSON(policy); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	err := mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, types.M{
+		mongo.AccessKey: policy,
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RegisterAccessPolicy(appName, policy); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
+
+// FetchAccessPolicy returns the access policy of an application along with the number of requests
+// rejected by it for every reason of rejection
+func FetchAccessPolicy(c *gin.Context) {
+	app, err := mongo.FetchSingleApp(c.Param("app"))
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	rejections, err := redis.FetchAccessRejections(c.Param("app"))
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success":    true,
+		"data":       app.Access,
+		"rejections": rejections,
+	})
+}
+
+// ResetAccessPolicy removes the access policy of an application so that all requests to it are let through
+func ResetAccessPolicy(c *gin.Context) {
+	appName := c.Param("app")
+	err := mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, types.M{
+		mongo.AccessKey: nil,
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RemoveAccessPolicy(appName); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/placement"
 	"github.com/sdslabs/gasper/types"
 )
 
@@ -62,6 +63,9 @@ func RevokeSuperuserPrivilege(c *gin.Context) {
 }
 
 // GetAllNodes fetches all the nodes registered on redis corresponding to their service
+// along with the resource capacities of the worker nodes, the labels of all nodes,
+// the cordoned nodes, the progress of draining nodes, the Master instance which is the leader
+// and the last heartbeats of all nodes holding the health of their microservices
 func GetAllNodes(c *gin.Context) {
 	services := configs.ServiceMap
 	res := gin.H{}
@@ -74,11 +78,47 @@ func GetAllNodes(c *gin.Context) {
 		}
 		res[service] = instances
 	}
+	capacities, err := redis.FetchNodeCapacities()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["capacities"] = capacities
+	labels, err := placement.NodeLabels()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["labels"] = labels
+	cordoned, err := redis.FetchCordonedNodes()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["cordoned"] = cordoned
+	drains, err := redis.FetchNodeDrains()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["drains"] = drains
+	leader, err := redis.FetchLeader()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["leader"] = leader
+	heartbeats, err := redis.FetchNodeHeartbeats()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	res["heartbeats"] = heartbeats
 	res["success"] = true
 	c.JSON(200, res)
 }
 
-// GetNodesByName fetches master nodes for 'master' and others for 'workers'
+// GetNodesByName fetches master nodes along with the leader for 'master' and others for 'workers'
 // Rest specific service nodes are returned
 func GetNodesByName(c *gin.Context) {
 	node := c.Param("type")
@@ -102,6 +142,12 @@ func GetNodesByName(c *gin.Context) {
 		return
 	case MasterNode:
 		node = types.Master
+		leader, err := redis.FetchLeader()
+		if err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+		res["leader"] = leader
 	default:
 		services := configs.ServiceMap
 		serviceExists := false
@@ -132,3 +178,48 @@ func GetNodesByName(c *gin.Context) {
 func DeleteUserByAdmin(c *gin.Context) {
 	deleteUser(c, c.Param("user"))
 }
+
+// AttachNodeLabels attaches labels to a node which are used for placing applications and databases
+// The labels replace the ones previously attached to the node and take precedence over the
+// labels defined in the node's configuration
+func AttachNodeLabels(c *gin.Context) {
+	if !validNode(c) {
+		return
+	}
+	node := c.Param("node")
+	labels := make(map[string]string)
+	if err := c.ShouldBindJSON(&labels); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	err := mongo.UpsertNodeLabels(types.M{
+		mongo.HostIPKey: node,
+	}, &types.NodeLabels{
+		HostIP: node,
+		Labels: labels,
+	})
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// DetachNodeLabels removes the labels attached to a node by admins
+func DetachNodeLabels(c *gin.Context) {
+	_, err := mongo.DeleteNodeLabels(types.M{
+		mongo.HostIPKey: c.Param("node"),
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -8,11 +9,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
 	"github.com/sdslabs/gasper/lib/factory"
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/services/master/middlewares"
+	"github.com/sdslabs/gasper/services/master/placement"
 	"github.com/sdslabs/gasper/types"
 )
 
@@ -102,23 +105,36 @@ func UpdateAppByName(c *gin.Context) {
 }
 
 // CreateApp creates an application via gRPC
+// The application is placed on a worker node satisfying its placement constraints
+// and having enough resources available for it
 func CreateApp(c *gin.Context) {
-	instanceURL, err := redis.GetLeastLoadedWorker()
+	data, err := c.GetRawData()
 	if err != nil {
-		utils.SendServerErrorResponse(c, err)
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract data from Request Body"))
 		return
 	}
-	if instanceURL == redis.ErrEmptySet {
+
+	requested := &types.ApplicationConfig{}
+	if err := json.Unmarshal(data, requested); err != nil {
 		c.AbortWithStatusJSON(400, gin.H{
 			"success": false,
-			"error":   "No worker instances available at the moment",
+			"error":   err.Error(),
 		})
 		return
 	}
 
-	data, err := c.GetRawData()
+	instanceURL, err := placement.Worker(requested.GetName(), requested.Resources, requested.Placement, nil, nil)
 	if err != nil {
-		utils.SendServerErrorResponse(c, errors.New("Failed to extract data from Request Body"))
+		if err == placement.ErrNoWorkers ||
+			err == placement.ErrInsufficientResources ||
+			err == placement.ErrUnsatisfiable {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
 		return
 	}
 
@@ -139,20 +155,69 @@ func CreateApp(c *gin.Context) {
 		} else if strings.Contains(err.Error(), "couldn't find remote ref") {
 			c.AbortWithStatusJSON(400, gin.H{
 				"success": false,
-				"error":   "Invalid git branch provided",
+				"error":   "Invalid git branch or ref provided",
+			})
+		} else if strings.Contains(err.Error(), "object not found") {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "Invalid git commit provided",
+			})
+		} else if strings.Contains(err.Error(), "failed to build") {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Image failed to build, view its logs with GET /apps/%s/builds", requested.GetName()),
 			})
 		} else {
 			utils.SendServerErrorResponse(c, err)
 		}
 		return
 	}
+
+	if requested.HTTPS != nil {
+		if err := redis.RegisterHTTPSPolicy(requested.GetName(), requested.HTTPS); err != nil {
+			utils.LogError("Master-Controller-Application-6", err)
+		}
+	}
+	if requested.Access != nil {
+		if err := redis.RegisterAccessPolicy(requested.GetName(), requested.Access); err != nil {
+			utils.LogError("Master-Controller-Application-7", err)
+		}
+	}
+
+	// The remaining replicas of the application are placed once it has been created
+	app := &types.ApplicationConfig{}
+	if err := json.Unmarshal(response, app); err != nil {
+		utils.LogError("Master-Controller-Application-4", err)
+	} else if app.GetReplicas() > 1 {
+		go func() {
+			if resErr := scaleApplication(app.GetName(), app.GetReplicas()); resErr != nil {
+				utils.LogError("Master-Controller-Application-5", resErr)
+			}
+		}()
+	}
 	c.Data(200, "application/json", response)
 }
 
+// failedAppNode returns the URL of the node holding an application whose image failed to build
+// Such applications are not registered in Redis as they don't serve requests
+func failedAppNode(appName string) (string, error) {
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return "", err
+	}
+	if app.GetState() != types.AppFailed {
+		return "", fmt.Errorf("Application %s has not failed", appName)
+	}
+	return fmt.Sprintf("%s:%d", app.HostIP, configs.ServiceConfig.AppMaker.Port), nil
+}
+
 // DeleteApp deletes an application via gRPC
 func DeleteApp(c *gin.Context) {
 	appName := c.Param("app")
 	instanceURL, err := redis.FetchAppNode(appName)
+	if err != nil {
+		instanceURL, err = failedAppNode(appName)
+	}
 	if err != nil {
 		c.AbortWithStatusJSON(400, gin.H{
 			"success": false,
@@ -161,6 +226,8 @@ func DeleteApp(c *gin.Context) {
 		return
 	}
 
+	deleteReplicas(appName)
+
 	response, err := factory.DeleteApplication(appName, instanceURL)
 	if err != nil {
 		utils.SendServerErrorResponse(c, err)
@@ -194,6 +261,34 @@ func FetchAppLogs(c *gin.Context) {
 	c.JSON(200, response)
 }
 
+// FetchAppBuilds returns the build history of an application
+func FetchAppBuilds(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    mongo.FetchBuilds(types.M{mongo.NameKey: c.Param("app")}),
+	})
+}
+
+// FetchAppBuildLogs returns a single build of an application along with the output of its build commands
+func FetchAppBuildLogs(c *gin.Context) {
+	build, err := mongo.FetchSingleBuild(c.Param("app"), c.Param("id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "No such build exists",
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    build,
+	})
+}
+
 // RebuildApp rebuilds an application via gRPC
 func RebuildApp(c *gin.Context) {
 	appName := c.Param("app")
@@ -206,7 +301,13 @@ func RebuildApp(c *gin.Context) {
 		return
 	}
 
-	response, err := factory.RebuildApplication(appName, instanceURL)
+	claims := middlewares.ExtractClaims(c)
+	if claims == nil {
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract JWT claims"))
+		return
+	}
+
+	response, err := factory.RebuildApplication(appName, claims.GetEmail(), instanceURL)
 	if err != nil {
 		utils.LogError("Master-Controller-Application-2", err)
 		if strings.Contains(err.Error(), "authentication required") {
@@ -217,7 +318,78 @@ func RebuildApp(c *gin.Context) {
 		} else if strings.Contains(err.Error(), "couldn't find remote ref") {
 			c.AbortWithStatusJSON(400, gin.H{
 				"success": false,
-				"error":   "Invalid git branch provided",
+				"error":   "Invalid git branch or ref provided",
+			})
+		} else if strings.Contains(err.Error(), "object not found") {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "Invalid git commit provided",
+			})
+		} else if strings.Contains(err.Error(), "is already being rebuilt") {
+			c.AbortWithStatusJSON(409, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Application %s is already being rebuilt", appName),
+			})
+		} else {
+			utils.SendServerErrorResponse(c, err)
+		}
+		return
+	}
+	c.Data(200, "application/json", response)
+}
+
+// FetchAppReleases returns the releases of an application
+func FetchAppReleases(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    mongo.FetchReleases(types.M{mongo.NameKey: c.Param("app")}),
+	})
+}
+
+// RollbackApp redeploys a release of an application via gRPC
+func RollbackApp(c *gin.Context) {
+	appName := c.Param("app")
+	releaseID := c.Param("release")
+
+	if _, err := mongo.FetchSingleRelease(appName, releaseID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "No such release exists",
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	instanceURL, err := redis.FetchAppNode(appName)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Application %s is not deployed at the moment", appName),
+		})
+		return
+	}
+
+	claims := middlewares.ExtractClaims(c)
+	if claims == nil {
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract JWT claims"))
+		return
+	}
+
+	response, err := factory.RollbackApplication(appName, releaseID, claims.GetEmail(), instanceURL)
+	if err != nil {
+		utils.LogError("Master-Controller-Application-3", err)
+		if strings.Contains(err.Error(), "authentication required") {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "Invalid git repository url or access token",
+			})
+		} else if strings.Contains(err.Error(), "object not found") {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "Commit of the release no longer exists in the git repository",
 			})
 		} else {
 			utils.SendServerErrorResponse(c, err)
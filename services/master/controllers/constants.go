@@ -5,6 +5,25 @@ const (
 	WorkerNode = "workers"
 	// MasterNode is the reference to the master nodes
 	MasterNode = "master"
+
+	// webhookSecretLength is the number of random bytes in an application's webhook secret
+	webhookSecretLength = 32
+
+	// domainTokenLength is the number of random bytes in the challenge token of a custom domain
+	domainTokenLength = 16
+
+	// webhookDeliveriesLimit is the maximum number of webhook deliveries returned for an application
+	webhookDeliveriesLimit = 50
+
+	// maxWebhookPayloadSize is the maximum size in bytes of a webhook payload
+	maxWebhookPayloadSize = 25 << 20
+
+	// defaultDrainConcurrency is the number of instances moved at once while draining a node
+	// when no concurrency is requested
+	defaultDrainConcurrency = 2
+
+	// maxDrainConcurrency is the maximum number of instances moved at once while draining a node
+	maxDrainConcurrency = 10
 )
 
 // timeConversionMap holds various units of time and their conversion
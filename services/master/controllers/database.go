@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"errors"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,7 @@ import (
 	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/services/master/middlewares"
+	"github.com/sdslabs/gasper/services/master/placement"
 	"github.com/sdslabs/gasper/types"
 )
 
@@ -34,27 +36,34 @@ func GetDatabaseInfo(c *gin.Context) {
 }
 
 // CreateDatabase creates a database via gRPC
+// The database is placed on the least loaded instance whose node satisfies its node selector
 func CreateDatabase(c *gin.Context) {
 	database := c.Param("database")
-	instanceURL, err := redis.GetLeastLoadedInstance(database)
+	data, err := c.GetRawData()
 	if err != nil {
-		c.AbortWithStatusJSON(400, gin.H{
-			"success": false,
-			"error":   err.Error(),
-		})
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract data from Request Body"))
 		return
 	}
-	if instanceURL == redis.ErrEmptySet {
+
+	requested := &types.DatabaseConfig{}
+	if err := json.Unmarshal(data, requested); err != nil {
 		c.AbortWithStatusJSON(400, gin.H{
 			"success": false,
-			"error":   "No worker instances available at the moment",
+			"error":   err.Error(),
 		})
 		return
 	}
 
-	data, err := c.GetRawData()
+	instanceURL, err := placement.Instance(database, requested.Placement)
 	if err != nil {
-		utils.SendServerErrorResponse(c, errors.New("Failed to extract data from Request Body"))
+		if err == placement.ErrNoWorkers || err == placement.ErrUnsatisfiable {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
 		return
 	}
 
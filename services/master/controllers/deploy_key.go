@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/git"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/middlewares"
+	"github.com/sdslabs/gasper/types"
+)
+
+type deployKeyRequest struct {
+	PrivateKey string `json:"private_key"`
+}
+
+// CreateDeployKey stores a deploy key for accessing the private git repository of an application over SSH
+// An ed25519 key pair is generated unless a private key is supplied in the request body
+// Any previous deploy key of the application is replaced
+func CreateDeployKey(c *gin.Context) {
+	appName := c.Param("app")
+	claims := middlewares.ExtractClaims(c)
+	if claims == nil {
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract JWT claims"))
+		return
+	}
+
+	req := &deployKeyRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	var privateKey []byte
+	var publicKey string
+	var err error
+	generated := req.PrivateKey == ""
+	if generated {
+		privateKey, publicKey, err = git.GenerateDeployKey()
+		if err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+	} else {
+		privateKey = []byte(req.PrivateKey)
+		publicKey, err = git.PublicDeployKey(privateKey)
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Invalid private key, only unencrypted PEM encoded keys are supported: %s", err.Error()),
+			})
+			return
+		}
+	}
+
+	encryptedKey, err := utils.Encrypt(privateKey)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	key := &types.DeployKey{
+		Name:       appName,
+		Owner:      claims.GetEmail(),
+		PublicKey:  publicKey,
+		PrivateKey: encryptedKey,
+		Generated:  generated,
+		CreatedAt:  time.Now().Unix(),
+	}
+	err = mongo.UpsertDeployKey(types.M{mongo.NameKey: appName}, key)
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    key,
+	})
+}
+
+// FetchDeployKey returns the public key of an application's deploy key
+func FetchDeployKey(c *gin.Context) {
+	key, err := mongo.FetchDeployKey(c.Param("app"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   "No deploy key exists for the application",
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    key,
+	})
+}
+
+// DeleteDeployKey deletes the deploy key of an application
+func DeleteDeployKey(c *gin.Context) {
+	_, err := mongo.DeleteDeployKey(types.M{mongo.NameKey: c.Param("app")})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
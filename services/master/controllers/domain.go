@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/cloudflare"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// hostnameRegex matches the fully qualified domain names which can be attached to an application
+var hostnameRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+type domainRequest struct {
+	Hostname string `json:"hostname"`
+	Method   string `json:"method"`
+}
+
+// validateHostname checks whether a hostname can be attached to an application as a custom domain
+// The domain on which Gasper is hosted and its subdomains are served by Gasper itself
+func validateHostname(hostname string) error {
+	if !hostnameRegex.MatchString(hostname) {
+		return fmt.Errorf("%s is not a valid hostname", hostname)
+	}
+	gasperDomain := configs.GasperConfig.Domain
+	if hostname == gasperDomain || strings.HasSuffix(hostname, "."+gasperDomain) {
+		return fmt.Errorf("Subdomains of %s cannot be attached as custom domains", gasperDomain)
+	}
+	return nil
+}
+
+// verifyDomainChallenge checks whether the challenge token of a domain has been published
+// through the domain's verification method
+func verifyDomainChallenge(domain *types.Domain) error {
+	if domain.Method == types.DNSChallenge {
+		records, err := net.LookupTXT(domain.ChallengeRecord())
+		if err != nil {
+			return fmt.Errorf("Failed to look up the TXT record %s: %s", domain.ChallengeRecord(), err.Error())
+		}
+		if !utils.Contains(records, domain.Token) {
+			return fmt.Errorf("TXT record %s doesn't hold the challenge token", domain.ChallengeRecord())
+		}
+		return nil
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	res, err := client.Get(domain.ChallengeURL())
+	if err != nil {
+		return fmt.Errorf("Failed to request %s: %s", domain.ChallengeURL(), err.Error())
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 200 || strings.TrimSpace(string(body)) != domain.Token {
+		return fmt.Errorf("%s doesn't respond with the challenge token", domain.ChallengeURL())
+	}
+	return nil
+}
+
+// fetchAppDomain returns a custom domain attached to the application in the request
+func fetchAppDomain(c *gin.Context) (*types.Domain, bool) {
+	domain, err := mongo.FetchDomain(strings.ToLower(c.Param("domain")))
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return nil, false
+	}
+	if err == mongo.ErrNoDocuments || domain.Name != c.Param("app") {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Domain %s is not attached to the application", c.Param("domain")),
+		})
+		return nil, false
+	}
+	return domain, true
+}
+
+// AttachDomain attaches a custom domain to an application
+// The application is served on the domain once its ownership has been verified through a TXT record
+// or a request answered by GenProxy, attaching the domain again issues a new challenge token
+func AttachDomain(c *gin.Context) {
+	appName := c.Param("app")
+	req := &domainRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	hostname := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(req.Hostname)), ".")
+	if err := validateHostname(hostname); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if req.Method == "" {
+		req.Method = types.DNSChallenge
+	}
+	if req.Method != types.DNSChallenge && req.Method != types.HTTPChallenge {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Field 'method' should be either `%s` or `%s`", types.DNSChallenge, types.HTTPChallenge),
+		})
+		return
+	}
+
+	existing, err := mongo.FetchDomain(hostname)
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err == nil {
+		if existing.Name != appName {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Domain %s is already attached to another application", hostname),
+			})
+			return
+		}
+		if existing.Verified {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Domain %s has already been verified", hostname),
+			})
+			return
+		}
+	}
+
+	token, err := utils.GenerateSecret(domainTokenLength)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	domain := &types.Domain{
+		Hostname:  hostname,
+		Name:      appName,
+		Method:    req.Method,
+		Token:     token,
+		CreatedAt: time.Now().Unix(),
+	}
+	err = mongo.UpsertDomain(types.M{mongo.HostnameKey: hostname}, domain)
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if domain.Method == types.HTTPChallenge {
+		if err := redis.RegisterDomainChallenge(hostname, token); err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+	}
+
+	c.JSON(200, gin.H{
+		"success":      true,
+		"data":         domain,
+		"instructions": domain.Instructions(),
+	})
+}
+
+// FetchDomains returns the custom domains attached to an application along with the state
+// of their certificates obtained over ACME
+func FetchDomains(c *gin.Context) {
+	domains := mongo.FetchDomains(types.M{mongo.NameKey: c.Param("app")})
+	hostnames := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if hostname, ok := domain[mongo.HostnameKey].(string); ok {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	certificates, err := mongo.FetchCertificates(types.M{
+		mongo.HostnameKey: types.M{"$in": hostnames},
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	for _, certificate := range certificates {
+		for _, domain := range domains {
+			if domain[mongo.HostnameKey] == certificate.Hostname {
+				domain["certificate"] = certificate
+			}
+		}
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    domains,
+	})
+}
+
+// VerifyDomain verifies the ownership of a custom domain attached to an application
+// and starts serving the application on it
+func VerifyDomain(c *gin.Context) {
+	domain, ok := fetchAppDomain(c)
+	if !ok {
+		return
+	}
+	if !domain.Verified {
+		if err := verifyDomainChallenge(domain); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success":      false,
+				"error":        fmt.Sprintf("Failed to verify domain %s: %s", domain.Hostname, err.Error()),
+				"instructions": domain.Instructions(),
+			})
+			return
+		}
+		domain.Verified = true
+		domain.VerifiedAt = time.Now().Unix()
+		if err := mongo.UpsertDomain(types.M{mongo.HostnameKey: domain.Hostname}, domain); err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+	}
+
+	if err := redis.RegisterDomain(domain.Hostname, domain.Name); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if configs.CloudflareConfig.PlugIn {
+		if _, err := cloudflare.CreateCustomHostname(domain.Hostname); err != nil {
+			utils.LogError("Master-Controller-Domain-1", err)
+		}
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    domain,
+	})
+}
+
+// DetachDomain detaches a custom domain from an application
+func DetachDomain(c *gin.Context) {
+	domain, ok := fetchAppDomain(c)
+	if !ok {
+		return
+	}
+	if err := redis.RemoveDomain(domain.Hostname); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if _, err := mongo.DeleteDomain(types.M{mongo.HostnameKey: domain.Hostname}); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	// The routes on the domain no longer have a hostname to be served on
+	routes, err := mongo.FetchRoutes(types.M{mongo.HostnameKey: domain.Hostname})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RemoveRoutes(routes...); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if _, err := mongo.DeleteRoutes(types.M{mongo.HostnameKey: domain.Hostname}); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if configs.CloudflareConfig.PlugIn && domain.Verified {
+		go cloudflare.DeleteCustomHostname(domain.Hostname)
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
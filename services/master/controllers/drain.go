@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/placement"
+	"github.com/sdslabs/gasper/types"
+)
+
+// drainRequest is the request body for draining a node
+type drainRequest struct {
+	Concurrency int `json:"concurrency"`
+}
+
+// databaseSkipReason is the reason reported for the databases present on a draining node
+// Re-creating a database on another node would leave its data behind on the draining node
+// hence databases are left in place for their data to be migrated by their owners
+const databaseSkipReason = "Databases are not moved as their data cannot be copied to another node"
+
+// drainTask moves a single instance off a draining node
+type drainTask struct {
+	kind string
+	name string
+	move func() error
+}
+
+// fetchNodeDrain returns the progress of draining a node, nil is returned if the node was never drained
+func fetchNodeDrain(hostIP string) (*types.Drain, error) {
+	drains, err := redis.FetchNodeDrains()
+	if err != nil {
+		return nil, err
+	}
+	return drains[hostIP], nil
+}
+
+// moveApplication places an application present on a draining node on another worker node and
+// then evicts it from the draining node, the application is deployed on the new node with the
+// commit and lifecycle state it had on the draining node
+func moveApplication(name string) error {
+	app, err := mongo.FetchSingleApp(name)
+	if err != nil {
+		return err
+	}
+	node, err := redis.FetchAppNode(name)
+	if err != nil {
+		return fmt.Errorf("Application %s is not deployed at the moment", name)
+	}
+
+	// Worker nodes holding replicas of the application cannot hold the application as well
+	peers := replicaNodes(mongo.FetchReplicas(types.M{mongo.NameKey: name}))
+	instanceURL, err := placement.Worker(name, app.Resources, app.Placement, peers, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := factory.RelocateApplication(name, instanceURL); err != nil {
+		return err
+	}
+	_, err = factory.EvictApplication(name, node)
+	return err
+}
+
+// moveReplica places a replica present on a draining node on another worker node
+// and then deletes it from the draining node
+func moveReplica(replica types.M) error {
+	name, ok := replica[mongo.NameKey].(string)
+	if !ok {
+		return fmt.Errorf("Replica has no application name")
+	}
+	node, ok := replica[mongo.NodeKey].(string)
+	if !ok {
+		return fmt.Errorf("Replica of application %s has no node", name)
+	}
+	app, err := mongo.FetchSingleApp(name)
+	if err != nil {
+		return err
+	}
+	appNode, err := redis.FetchAppNode(name)
+	if err != nil {
+		return fmt.Errorf("Application %s is not deployed at the moment", name)
+	}
+
+	peers := append(replicaNodes(mongo.FetchReplicas(types.M{mongo.NameKey: name})), appNode)
+	instanceURL, err := placement.Worker(name, app.Resources, app.Placement, peers, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := factory.CreateApplicationReplica(name, instanceURL); err != nil {
+		return err
+	}
+	_, err = factory.DeleteApplicationReplica(name, node)
+	return err
+}
+
+// drainTasks returns the tasks for moving all applications and replicas off a node along with
+// the databases present on the node which are skipped
+func drainTasks(hostIP string) ([]drainTask, []types.DrainSkip) {
+	filter := types.M{
+		mongo.HostIPKey: hostIP,
+	}
+	tasks := make([]drainTask, 0)
+	for _, app := range mongo.FetchAppInfo(filter) {
+		name, _ := app[mongo.NameKey].(string)
+		tasks = append(tasks, drainTask{
+			kind: types.DrainedApplication,
+			name: name,
+			move: func() error { return moveApplication(name) },
+		})
+	}
+	for _, replica := range mongo.FetchReplicas(filter) {
+		replica := replica
+		name, _ := replica[mongo.NameKey].(string)
+		tasks = append(tasks, drainTask{
+			kind: types.DrainedReplica,
+			name: name,
+			move: func() error { return moveReplica(replica) },
+		})
+	}
+	skipped := make([]types.DrainSkip, 0)
+	for _, db := range mongo.FetchDBInfo(filter) {
+		name, _ := db[mongo.NameKey].(string)
+		skipped = append(skipped, types.DrainSkip{
+			Kind:   types.DrainedDatabase,
+			Name:   name,
+			Reason: databaseSkipReason,
+		})
+	}
+	return tasks, skipped
+}
+
+// drainNode runs the tasks for moving instances off a node with at most drain.Concurrency
+// tasks running at once and registers the progress of the drain after every task
+func drainNode(drain *types.Drain, tasks []drainTask) {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, drain.Concurrency)
+
+	for _, task := range tasks {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(task drainTask) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			err := task.move()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				utils.LogError("Master-Controller-Drain-1", fmt.Errorf("%s %s cannot be moved off node %s: %s",
+					task.kind, task.name, drain.HostIP, err.Error()))
+				drain.Failed = append(drain.Failed, types.DrainFailure{
+					Kind:  task.kind,
+					Name:  task.name,
+					Error: err.Error(),
+				})
+			} else {
+				drain.Moved++
+			}
+			if err := redis.RegisterNodeDrain(drain); err != nil {
+				utils.LogError("Master-Controller-Drain-2", err)
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	drain.State = types.NodeDrained
+	drain.FinishedAt = time.Now().Unix()
+	if err := redis.RegisterNodeDrain(drain); err != nil {
+		utils.LogError("Master-Controller-Drain-3", err)
+	}
+	utils.LogInfo("Master-Controller-Drain-4", "Drained node %s, moved %d of %d instances", drain.HostIP, drain.Moved, drain.Total)
+}
+
+// CordonNode stops new applications, replicas and databases from being placed on a node
+func CordonNode(c *gin.Context) {
+	if !validNode(c) {
+		return
+	}
+	if err := redis.CordonNode(c.Param("node")); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// UncordonNode returns a node to the pool of nodes on which instances are placed
+func UncordonNode(c *gin.Context) {
+	if !validNode(c) {
+		return
+	}
+	node := c.Param("node")
+	drain, err := fetchNodeDrain(node)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if drain != nil && drain.IsRunning() {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Node %s is being drained at the moment", node),
+		})
+		return
+	}
+	if err := redis.UncordonNode(node); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RemoveNodeDrain(node); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// DrainNode cordons a node and moves all applications and replicas present on it
+// to other nodes in the background, the progress of the drain is listed along with the nodes
+func DrainNode(c *gin.Context) {
+	if !validNode(c) {
+		return
+	}
+	node := c.Param("node")
+
+	req := &drainRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+	if req.Concurrency == 0 {
+		req.Concurrency = defaultDrainConcurrency
+	}
+	if req.Concurrency < 1 || req.Concurrency > maxDrainConcurrency {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Field 'concurrency' should be between 1 and %d", maxDrainConcurrency),
+		})
+		return
+	}
+
+	drain, err := fetchNodeDrain(node)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if drain != nil && drain.IsRunning() {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Node %s is already being drained", node),
+		})
+		return
+	}
+
+	if err := redis.CordonNode(node); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	tasks, skipped := drainTasks(node)
+	drain = &types.Drain{
+		HostIP:      node,
+		State:       types.NodeDraining,
+		Concurrency: req.Concurrency,
+		Total:       len(tasks),
+		Failed:      []types.DrainFailure{},
+		Skipped:     skipped,
+		StartedAt:   time.Now().Unix(),
+	}
+	if err := redis.RegisterNodeDrain(drain); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	utils.LogInfo("Master-Controller-Drain-5", "Draining node %s, moving %d instances and skipping %d", node, len(tasks), len(skipped))
+
+	// The response holds the progress at the start of the drain
+	// which then continues in the background
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    drain,
+	})
+	go drainNode(drain, tasks)
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sdslabs/gasper/lib/mongo"
@@ -23,6 +25,12 @@ var immutableFields = []string{
 	"cloudflare_id",
 	"app_url",
 	"docker_image",
+	mongo.StateKey,
+	mongo.RebuildStateKey,
+	"commit",
+	mongo.ReplicasKey,
+	mongo.HTTPSKey,
+	mongo.AccessKey,
 }
 
 func validateUpdatePayload(data types.M) error {
@@ -35,6 +43,27 @@ func validateUpdatePayload(data types.M) error {
 	if res != "" {
 		return errors.New(res)
 	}
+	// The git repository is replaced as a whole hence it is validated as it is while creating an application
+	if gitData, ok := data[mongo.GitKey]; ok {
+		gitJSON, err := json.Marshal(gitData)
+		if err != nil {
+			return err
+		}
+		repo := &types.Git{}
+		if err := json.Unmarshal(gitJSON, repo); err != nil {
+			return fmt.Errorf("Field `%s` is invalid: %s", mongo.GitKey, err)
+		}
+		if err := middlewares.ValidateGit(repo); err != nil {
+			return err
+		}
+		data[mongo.GitKey] = repo
+	}
+	if idleTimeout, ok := data[mongo.IdleTimeoutKey]; ok {
+		minutes, isNumber := idleTimeout.(float64)
+		if !isNumber || minutes != float64(int(minutes)) || minutes < 0 || (minutes > 0 && minutes < types.MinIdleTimeout) {
+			return fmt.Errorf("Field `%s` should be 0 or at least %d minutes", mongo.IdleTimeoutKey, types.MinIdleTimeout)
+		}
+	}
 	return nil
 }
 
@@ -151,3 +180,15 @@ func deleteUser(c *gin.Context, userEmail string) {
 		"message": "user deleted",
 	})
 }
+
+// validNode checks whether the node in the request's URL is referred to by its IP address
+func validNode(c *gin.Context) bool {
+	if net.ParseIP(c.Param("node")) == nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   "Node must be referred to by its IP address",
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// SetHTTPSPolicy sets the policy with which GenProxy treats the HTTP requests to an application
+// along with the HSTS header sent with its HTTPS responses
+func SetHTTPSPolicy(c *gin.Context) {
+	appName := c.Param("app")
+	policy := &types.HTTPSPolicy{}
+	if err := c.ShouldBindJSON(policy); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	err := mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, types.M{
+		mongo.HTTPSKey: policy,
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RegisterHTTPSPolicy(appName, policy); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
+
+// ResetHTTPSPolicy removes the HTTPS policy of an application so that GenProxy applies
+// the default policy from its configuration
+func ResetHTTPSPolicy(c *gin.Context) {
+	appName := c.Param("app")
+	err := mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, types.M{
+		mongo.HTTPSKey: nil,
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RemoveHTTPSPolicy(appName); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/factory"
+	pb "github.com/sdslabs/gasper/lib/factory/protos/application"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// lifecycleRPC is a remote procedure call changing the lifecycle of an application or its replica in a worker node
+type lifecycleRPC func(name, instanceURL string) (*pb.GenericResponse, error)
+
+// deployingStates are the states of an application whose deployment is in progress
+var deployingStates = []string{types.AppCloning, types.AppBuilding, types.AppStarting}
+
+// changeLifecycle invokes a lifecycle procedure for an application on its node and then on the nodes
+// of its replicas, the application must be in one of the allowed states
+// The application is marked (if required) before the procedure so that requests to it are handled as per
+// its new lifecycle right away, the mark is removed if the procedure fails on the application's node
+// Failures on the nodes of the replicas are only logged as the application itself has already changed
+func changeLifecycle(c *gin.Context, rpc lifecycleRPC, allowed func(state string) error, mark func(marked bool) error) bool {
+	appName := c.Param("app")
+	node, err := redis.FetchAppNode(appName)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Application %s is not deployed at the moment", appName),
+		})
+		return false
+	}
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return false
+	}
+	if err := allowed(app.GetState()); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return false
+	}
+
+	if mark != nil {
+		if err := mark(true); err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return false
+		}
+	}
+	if _, err := rpc(appName, node); err != nil {
+		if mark != nil {
+			if err := mark(false); err != nil {
+				utils.LogError("Master-Controller-Lifecycle-3", err)
+			}
+		}
+		utils.SendServerErrorResponse(c, err)
+		return false
+	}
+	for _, worker := range replicaNodes(mongo.FetchReplicas(types.M{mongo.NameKey: appName})) {
+		if _, err := rpc(appName, worker); err != nil {
+			utils.LogError("Master-Controller-Lifecycle-1", err)
+		}
+	}
+	return true
+}
+
+// StopApp stops an application along with its replicas via gRPC
+// Requests to a stopped application are answered with a page saying so
+func StopApp(c *gin.Context) {
+	appName := c.Param("app")
+	sleeping := false
+	ok := changeLifecycle(c, factory.StopApplication, func(state string) error {
+		if state == types.AppStopped {
+			return fmt.Errorf("Application %s is already stopped", appName)
+		}
+		if utils.Contains(deployingStates, state) {
+			return fmt.Errorf("Application %s is being deployed at the moment", appName)
+		}
+		sleeping = state == types.AppSleeping
+		return nil
+	}, func(stopped bool) error {
+		if err := redis.MarkAppStopped(appName, stopped); err != nil {
+			return err
+		}
+		// A sleeping application which couldn't be stopped is put back to sleep
+		if !stopped && sleeping {
+			return redis.MarkAppSleeping(appName, true)
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// StartApp starts a stopped or sleeping application along with its replicas via gRPC
+func StartApp(c *gin.Context) {
+	appName := c.Param("app")
+	ok := changeLifecycle(c, factory.StartApplication, func(state string) error {
+		if state != types.AppStopped && state != types.AppSleeping {
+			return fmt.Errorf("Application %s is not stopped", appName)
+		}
+		return nil
+	}, nil)
+	if !ok {
+		return
+	}
+	if err := redis.MarkAppStopped(appName, false); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	// The start counts as a request so that an idle application isn't put to sleep right away
+	if err := redis.RecordAppActivity(map[string]int64{appName: time.Now().Unix()}); err != nil {
+		utils.LogError("Master-Controller-Lifecycle-2", err)
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// RestartApp restarts a running application along with its replicas via gRPC
+func RestartApp(c *gin.Context) {
+	appName := c.Param("app")
+	ok := changeLifecycle(c, factory.RestartApplication, func(state string) error {
+		if state == types.AppStopped {
+			return fmt.Errorf("Application %s is stopped, start it instead", appName)
+		}
+		if state == types.AppSleeping {
+			return fmt.Errorf("Application %s is sleeping, start it instead", appName)
+		}
+		if utils.Contains(deployingStates, state) {
+			return fmt.Errorf("Application %s is being deployed at the moment", appName)
+		}
+		return nil
+	}, nil)
+	if !ok {
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
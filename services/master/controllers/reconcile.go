@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// reconcilers holds the services which reconcile the containers of their node along with the remote procedure call doing so
+var reconcilers = []struct {
+	service   string
+	reconcile func(bool, string) ([]byte, error)
+}{
+	{service: types.AppMaker, reconcile: factory.ReconcileApplications},
+	{service: types.DbMaker, reconcile: factory.ReconcileDatabases},
+}
+
+// nodeInstance returns the instance of a service deployed on a node, an empty string is returned if there is none
+func nodeInstance(service, hostIP string) (string, error) {
+	instances, err := redis.FetchServiceInstances(service)
+	if err != nil {
+		return "", err
+	}
+	for _, instance := range instances {
+		if host, _, err := net.SplitHostPort(instance); err == nil && host == hostIP {
+			return instance, nil
+		}
+	}
+	return "", nil
+}
+
+// GetReconcileReport returns the differences between the instances stored for a node and the containers and
+// storage directories present on it along with the actions which reconciling them would take
+// No action is taken, the reconcilers of the node act on their findings on their own
+func GetReconcileReport(c *gin.Context) {
+	if !validNode(c) {
+		return
+	}
+	node := c.Param("node")
+
+	reports := make([]*types.ReconcileReport, 0, len(reconcilers))
+	for _, reconciler := range reconcilers {
+		instance, err := nodeInstance(reconciler.service, node)
+		if err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+		if instance == "" {
+			continue
+		}
+		response, err := reconciler.reconcile(true, instance)
+		if err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+		report := &types.ReconcileReport{}
+		if err := json.Unmarshal(response, report); err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+		reports = append(reports, report)
+	}
+
+	if len(reports) == 0 {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Neither AppMaker nor DbMaker is deployed on node %s", node),
+		})
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    reports,
+	})
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/placement"
+	"github.com/sdslabs/gasper/types"
+)
+
+type scaleRequest struct {
+	Replicas int `json:"replicas"`
+}
+
+// replicaNodes returns the nodes on which the replicas of an application are deployed
+func replicaNodes(replicas []types.M) []string {
+	nodes := make([]string, 0)
+	for _, replica := range replicas {
+		if node, ok := replica[mongo.NodeKey].(string); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// recordReplicas stores the number of replicas an application is deployed as
+// The application itself is its first replica
+func recordReplicas(appName string) error {
+	return mongo.UpdateInstance(types.M{
+		mongo.NameKey:         appName,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}, types.M{
+		mongo.ReplicasKey: len(mongo.FetchReplicas(types.M{mongo.NameKey: appName})) + 1,
+	})
+}
+
+// scaleApplication changes the number of replicas of an application by placing new replicas on the
+// workers which satisfy its placement constraints, have enough resources available and don't have
+// a replica of the application yet
+// or by removing the newest replicas
+// The number of replicas which are actually deployed is stored afterwards, even if scaling fails midway
+func scaleApplication(appName string, replicas int) (resErr types.ResponseError) {
+	node, err := redis.FetchAppNode(appName)
+	if err != nil {
+		return types.NewResErr(400, fmt.Sprintf("Application %s is not deployed at the moment", appName), err)
+	}
+	defer func() {
+		if err := recordReplicas(appName); err != nil {
+			utils.LogError("Master-Controller-Replica-6", err)
+			if resErr == nil {
+				resErr = types.NewResErr(500, "", err)
+			}
+		}
+	}()
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		return types.NewResErr(500, "", err)
+	}
+
+	current := mongo.FetchReplicas(types.M{mongo.NameKey: appName})
+	occupied := append(replicaNodes(current), node)
+
+	// The application itself is its first replica
+	diff := replicas - len(occupied)
+
+	var workers []string
+	if diff > 0 {
+		workers, err = placement.Workers(appName, app.Resources, app.Placement, diff, occupied)
+		if err == placement.ErrNoWorkers {
+			return types.NewResErr(400, "", err)
+		}
+		if err != nil {
+			return types.NewResErr(500, "", err)
+		}
+		if len(workers) < diff {
+			return types.NewResErr(400, fmt.Sprintf(
+				"Only %d worker instances satisfy the placement constraints and have enough resources available for replicas of application %s at the moment",
+				len(occupied)+len(workers), appName), nil)
+		}
+	}
+
+	for _, worker := range workers {
+		utils.LogInfo("Master-Controller-Replica-1", "Placing replica of application %s on %s", appName, worker)
+		if _, err := factory.CreateApplicationReplica(appName, worker); err != nil {
+			utils.LogError("Master-Controller-Replica-2", err)
+			return types.NewResErr(500, fmt.Sprintf("Failed to place replica of application %s on %s", appName, worker), err)
+		}
+	}
+
+	if diff >= 0 {
+		return nil
+	}
+
+	// Replicas are fetched from the oldest to the newest hence the newest ones are removed
+	for _, worker := range replicaNodes(current[len(current)+diff:]) {
+		utils.LogInfo("Master-Controller-Replica-3", "Removing replica of application %s from %s", appName, worker)
+		if _, err := factory.DeleteApplicationReplica(appName, worker); err != nil {
+			utils.LogError("Master-Controller-Replica-4", err)
+			return types.NewResErr(500, fmt.Sprintf("Failed to remove replica of application %s from %s", appName, worker), err)
+		}
+	}
+	return nil
+}
+
+// deleteReplicas deletes all replicas of an application
+func deleteReplicas(appName string) {
+	for _, worker := range replicaNodes(mongo.FetchReplicas(types.M{mongo.NameKey: appName})) {
+		if _, err := factory.DeleteApplicationReplica(appName, worker); err != nil {
+			utils.LogError("Master-Controller-Replica-5", err)
+		}
+	}
+}
+
+// ScaleApp changes the number of replicas of an application via gRPC
+func ScaleApp(c *gin.Context) {
+	appName := c.Param("app")
+	req := &scaleRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if req.Replicas < 1 || req.Replicas > types.MaxReplicas {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Field 'replicas' should be between 1 and %d", types.MaxReplicas),
+		})
+		return
+	}
+
+	if resErr := scaleApplication(appName, req.Replicas); resErr != nil {
+		if resErr.Status() == 400 {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   resErr.Message(),
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, resErr)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success":  true,
+		"replicas": req.Replicas,
+		"data":     mongo.FetchReplicas(types.M{mongo.NameKey: appName}),
+	})
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// GetRescheduleJobs returns the jobs for rescheduling the applications present on lost nodes
+// in the order in which they were enqueued, the jobs can be filtered by their state
+func GetRescheduleJobs(c *gin.Context) {
+	jobs, err := redis.FetchRescheduleJobs()
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	state := c.Query("state")
+	filtered := make([]*types.RescheduleJob, 0, len(jobs))
+	for _, job := range jobs {
+		if state == "" || job.State == state {
+			filtered = append(filtered, job)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].EnqueuedAt < filtered[j].EnqueuedAt
+	})
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    filtered,
+	})
+}
+
+// fetchRescheduleJob returns the rescheduling job of the application in the request's URL
+func fetchRescheduleJob(c *gin.Context) *types.RescheduleJob {
+	appName := c.Param("app")
+	job, err := redis.FetchRescheduleJob(appName)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return nil
+	}
+	if job == nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Application %s is not waiting to be rescheduled", appName),
+		})
+	}
+	return job
+}
+
+// RetryRescheduleJob queues the rescheduling job of an application for an immediate attempt
+// with its attempts reset, stuck jobs are retried this way once their cause has been fixed
+func RetryRescheduleJob(c *gin.Context) {
+	job := fetchRescheduleJob(c)
+	if job == nil {
+		return
+	}
+	if job.State == types.RescheduleRunning {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Application %s is being rescheduled at the moment", job.Name),
+		})
+		return
+	}
+	job.State = types.RescheduleQueued
+	job.Attempts = 0
+	job.FailedNodes = []string{}
+	job.NextAttemptAt = time.Now().Unix()
+	if err := redis.RegisterRescheduleJob(job); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// DeleteRescheduleJob removes the rescheduling job of an application
+// so that the application is no longer rescheduled
+func DeleteRescheduleJob(c *gin.Context) {
+	job := fetchRescheduleJob(c)
+	if job == nil {
+		return
+	}
+	if err := redis.RemoveRescheduleJob(job.Name); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/middlewares"
+	"github.com/sdslabs/gasper/types"
+)
+
+// headerNameRegex matches the names of the headers which can be rewritten by a route
+var headerNameRegex = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+type routeRequest struct {
+	Hostname    string              `json:"hostname"`
+	Path        string              `json:"path"`
+	Target      string              `json:"target"`
+	StripPrefix bool                `json:"strip_prefix"`
+	Headers     *types.RouteHeaders `json:"headers"`
+}
+
+// appHostname returns the subdomain of the root domain on which an application is served
+func appHostname(appName string) string {
+	return fmt.Sprintf("%s.app.%s", appName, configs.GasperConfig.Domain)
+}
+
+// validateRouteHostname checks whether a hostname belongs to an application, which is the case for the
+// application's subdomain of the root domain and its verified custom domains
+func validateRouteHostname(appName, hostname string) error {
+	if hostname == appHostname(appName) {
+		return nil
+	}
+	domain, err := mongo.FetchDomain(hostname)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+	if err == mongo.ErrNoDocuments || domain.Name != appName || !domain.Verified {
+		return fmt.Errorf("Hostname %s is neither the subdomain nor a verified custom domain of application %s", hostname, appName)
+	}
+	return nil
+}
+
+// validateRouteHeaders checks whether the headers rewritten by a route are valid
+// The Host header is always set to the address of the application by GenProxy hence it cannot be rewritten
+func validateRouteHeaders(headers *types.RouteHeaders) error {
+	if headers == nil {
+		return nil
+	}
+	names := append([]string{}, headers.Remove...)
+	for name := range headers.Set {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		if !headerNameRegex.MatchString(name) {
+			return fmt.Errorf("%s is not a valid header name", name)
+		}
+		if strings.EqualFold(name, "Host") {
+			return fmt.Errorf("Header Host cannot be rewritten")
+		}
+	}
+	return nil
+}
+
+// AddRoute serves an application on the requests to a hostname of another application whose path starts with a prefix
+// The application owning the hostname can route its own requests as well, adding a route for the same hostname
+// and path again replaces the route
+func AddRoute(c *gin.Context) {
+	appName := c.Param("app")
+	claims := middlewares.ExtractClaims(c)
+	if claims == nil {
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract JWT claims"))
+		return
+	}
+	req := &routeRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	hostname := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(req.Hostname)), ".")
+	if hostname == "" {
+		hostname = appHostname(appName)
+	}
+	if err := validateRouteHostname(appName, hostname); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	path, err := types.NormalizeRoutePath(req.Path)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err := validateRouteHeaders(req.Headers); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Only the applications owned by the same user can be served on the hostname
+	if req.Target == "" {
+		req.Target = appName
+	}
+	filter := types.M{
+		mongo.NameKey:         req.Target,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	}
+	if !claims.IsAdmin() {
+		filter[mongo.OwnerKey] = claims.GetEmail()
+	}
+	count, err := mongo.CountInstances(filter)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if count == 0 {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("User %s doesn't own an application named %s", claims.GetEmail(), req.Target),
+		})
+		return
+	}
+
+	route := &types.Route{
+		Hostname:    hostname,
+		Path:        path,
+		Name:        appName,
+		Target:      req.Target,
+		StripPrefix: req.StripPrefix,
+		Headers:     req.Headers,
+		CreatedAt:   time.Now().Unix(),
+	}
+	err = mongo.UpsertRoute(types.M{
+		mongo.HostnameKey: hostname,
+		mongo.PathKey:     path,
+	}, route)
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if err := redis.RegisterRoute(route); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    route,
+	})
+}
+
+// FetchRoutes returns the routes on the hostnames of an application
+func FetchRoutes(c *gin.Context) {
+	routes, err := mongo.FetchRoutes(types.M{mongo.NameKey: c.Param("app")})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    routes,
+	})
+}
+
+// DeleteRoute deletes a route on a hostname of an application
+// The route is picked by the `hostname` and `path` query parameters
+func DeleteRoute(c *gin.Context) {
+	path, err := types.NormalizeRoutePath(c.Query("path"))
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	hostname := strings.ToLower(c.Query("hostname"))
+	if hostname == "" {
+		hostname = appHostname(c.Param("app"))
+	}
+	filter := types.M{
+		mongo.NameKey:     c.Param("app"),
+		mongo.HostnameKey: hostname,
+		mongo.PathKey:     path,
+	}
+	routes, err := mongo.FetchRoutes(filter)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if len(routes) == 0 {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("No route exists for path %s on %s", path, hostname),
+		})
+		return
+	}
+	if err := redis.RemoveRoutes(routes...); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if _, err := mongo.DeleteRoutes(filter); err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
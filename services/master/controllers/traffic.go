@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// defaultTrafficSpan is the time span (in seconds) of the traffic returned when none is specified
+const defaultTrafficSpan = 3600
+
+// FetchTraffic retrieves the per minute aggregates of the requests to an application served by GenProxy
+// The aggregates of all GenProxy instances are merged along with a summary of the whole time span
+func FetchTraffic(c *gin.Context) {
+	appName := c.Param("app")
+	query := c.Request.URL.Query()
+	var timeSpan int64
+	for unit, converter := range timeConversionMap {
+		if val := query.Get(unit); val != "" {
+			timeVal, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				continue
+			}
+			timeSpan += timeVal * converter
+		}
+	}
+	if timeSpan <= 0 {
+		timeSpan = defaultTrafficSpan
+	}
+
+	since := time.Now().Unix() - timeSpan
+	records, err := mongo.FetchTraffic(types.M{
+		mongo.NameKey: appName,
+		mongo.TimestampKey: types.M{
+			"$gte": since,
+		},
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	total := types.NewTraffic(appName, since)
+	series := make([]types.M, 0)
+	var current *types.Traffic
+	for _, record := range records {
+		if current == nil || current.Timestamp != record.Timestamp {
+			if current != nil {
+				series = append(series, current.Summary())
+			}
+			current = types.NewTraffic(appName, record.Timestamp)
+		}
+		current.Merge(record)
+		total.Merge(record)
+	}
+	if current != nil {
+		series = append(series, current.Summary())
+	}
+
+	summary := total.Summary()
+	summary["requests_per_minute"] = float64(total.Requests) / (float64(timeSpan) / 60)
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    series,
+		"summary": summary,
+	})
+}
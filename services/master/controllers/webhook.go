@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/lib/webhook"
+	"github.com/sdslabs/gasper/types"
+)
+
+// recordDelivery stores a webhook delivery with the given status and message
+func recordDelivery(delivery *types.WebhookDelivery, status, message string) {
+	delivery.Status = status
+	delivery.Message = message
+	if _, err := mongo.RegisterWebhookDelivery(delivery); err != nil {
+		utils.LogError("Master-Controller-Webhook-1", err)
+	}
+}
+
+// rebuildFromWebhook rebuilds an application via gRPC and updates
+// the webhook delivery which triggered the rebuild with the outcome
+func rebuildFromWebhook(delivery *types.WebhookDelivery, instanceURL string) {
+	update := types.M{"status": types.DeliverySucceeded}
+	if _, err := factory.RebuildApplication(delivery.Name, fmt.Sprintf("%s webhook", delivery.Provider), instanceURL); err != nil {
+		utils.LogError("Master-Controller-Webhook-2", err)
+		update = types.M{
+			"status":  types.DeliveryFailed,
+			"message": err.Error(),
+		}
+	}
+	err := mongo.UpdateWebhookDelivery(types.M{
+		mongo.NameKey:       delivery.Name,
+		mongo.DeliveryIDKey: delivery.ID,
+	}, update)
+	if err != nil {
+		utils.LogError("Master-Controller-Webhook-3", err)
+	}
+}
+
+// HandleAppWebhook verifies a push webhook from GitHub, GitLab or Gitea and rebuilds
+// the application if the commits were pushed to its deployed branch
+func HandleAppWebhook(c *gin.Context) {
+	appName := c.Param("app")
+	hook, err := mongo.FetchWebhook(appName)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.AbortWithStatusJSON(404, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Webhook for application %s is not enabled", appName),
+			})
+			return
+		}
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	// The endpoint is not authenticated hence the payload is read only up to the size GitHub caps it at
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize)
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithStatusJSON(413, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Payload must not be larger than %d bytes", maxWebhookPayloadSize),
+		})
+		return
+	}
+
+	// Deliveries with an invalid signature or payload are not recorded as anyone can send them
+	push, err := webhook.Parse(c.Request.Header, payload, hook.Secret)
+	if err != nil {
+		status := 400
+		if err == webhook.ErrInvalidSignature {
+			status = 401
+		}
+		c.AbortWithStatusJSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	delivery := &types.WebhookDelivery{
+		ID:        push.DeliveryID,
+		Name:      appName,
+		Provider:  push.Provider,
+		Event:     push.Event,
+		Ref:       push.Ref,
+		Commit:    push.Commit,
+		Timestamp: time.Now().Unix(),
+	}
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+
+	if !push.IsPush() {
+		message := fmt.Sprintf("Event `%s` does not trigger a rebuild", push.Event)
+		recordDelivery(delivery, types.DeliveryIgnored, message)
+		c.JSON(200, gin.H{
+			"success": true,
+			"message": message,
+		})
+		return
+	}
+
+	app, err := mongo.FetchSingleApp(appName)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	if app.IsGitPinned() {
+		message := fmt.Sprintf("Application %s is pinned to a git tag or commit", appName)
+		recordDelivery(delivery, types.DeliveryIgnored, message)
+		c.JSON(200, gin.H{
+			"success": true,
+			"message": message,
+		})
+		return
+	}
+
+	if branch := push.Branch(); branch != app.GetGitRepositoryBranch() {
+		message := fmt.Sprintf("Push to `%s` does not match the deployed branch `%s`", push.Ref, app.GetGitRepositoryBranch())
+		recordDelivery(delivery, types.DeliveryIgnored, message)
+		c.JSON(200, gin.H{
+			"success": true,
+			"message": message,
+		})
+		return
+	}
+
+	instanceURL, err := redis.FetchAppNode(appName)
+	if err != nil {
+		message := fmt.Sprintf("Application %s is not deployed at the moment", appName)
+		recordDelivery(delivery, types.DeliveryFailed, message)
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   message,
+		})
+		return
+	}
+
+	recordDelivery(delivery, types.DeliveryTriggered, "")
+	go rebuildFromWebhook(delivery, instanceURL)
+
+	c.JSON(202, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("Rebuild of application %s triggered", appName),
+	})
+}
+
+// RotateAppWebhookSecret enables the webhook of an application by generating
+// a new secret, any previously generated secret stops working
+func RotateAppWebhookSecret(c *gin.Context) {
+	appName := c.Param("app")
+	secret, err := utils.GenerateSecret(webhookSecretLength)
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	err = mongo.UpsertWebhook(types.M{mongo.NameKey: appName}, &types.Webhook{
+		Name:      appName,
+		Secret:    secret,
+		UpdatedAt: time.Now().Unix(),
+	})
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"secret":  secret,
+		"path":    fmt.Sprintf("/webhooks/%s", appName),
+	})
+}
+
+// DisableAppWebhook removes the webhook secret of an application
+func DisableAppWebhook(c *gin.Context) {
+	_, err := mongo.DeleteWebhook(types.M{mongo.NameKey: c.Param("app")})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+// FetchAppWebhookDeliveries returns the latest webhook deliveries of an application
+func FetchAppWebhookDeliveries(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    mongo.FetchWebhookDeliveries(types.M{mongo.NameKey: c.Param("app")}, webhookDeliveriesLimit),
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/docker"
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/redis"
 	"github.com/sdslabs/gasper/lib/utils"
@@ -41,22 +42,52 @@ func fetchBoundDatabases(currentIP, service string) []types.M {
 	)
 }
 
+// fetchReplicaBindings returns the bindings of the replicas of the given applications
+func fetchReplicaBindings(instances []types.M) map[string][]types.InstanceBindings {
+	names := make([]string, 0)
+	for _, instance := range instances {
+		names = append(names, instance[mongo.NameKey].(string))
+	}
+	bindings := make(map[string][]types.InstanceBindings)
+	if len(names) == 0 {
+		return bindings
+	}
+	replicas := mongo.FetchReplicas(types.M{
+		mongo.NameKey: types.M{
+			"$in": names,
+		},
+	})
+	for _, replica := range replicas {
+		name := replica[mongo.NameKey].(string)
+		bindings[name] = append(bindings[name], types.InstanceBindings{
+			Node:   fmt.Sprintf("%v", replica[mongo.NodeKey]),
+			Server: fmt.Sprintf("%v:%v", replica[mongo.HostIPKey], replica[mongo.ContainerPortKey]),
+		})
+	}
+	return bindings
+}
+
+// registerApps refreshes the bindings of the applications deployed on the current node
+// The bindings are updated one by one so that the lifecycle of the applications marked
+// in them is retained and the changes are published
 func registerApps(instances []types.M, currentIP string, config *configs.GenericService) {
-	payload := make(types.M)
+	replicaBindings := fetchReplicaBindings(instances)
 	for _, instance := range instances {
+		// Applications being cloned and those whose image failed to build have no container yet
+		if fmt.Sprintf("%v", instance[mongo.ContainerPortKey]) == "0" {
+			continue
+		}
+		name := instance[mongo.NameKey].(string)
 		appBind := &types.InstanceBindings{
-			Node:   fmt.Sprintf("%s:%d", currentIP, config.Port),
-			Server: fmt.Sprintf("%s:%v", currentIP, instance[mongo.ContainerPortKey]),
+			Node:     fmt.Sprintf("%s:%d", currentIP, config.Port),
+			Server:   fmt.Sprintf("%s:%v", currentIP, instance[mongo.ContainerPortKey]),
+			Replicas: replicaBindings[name],
+			Stopped:  instance[mongo.StateKey] == types.AppStopped,
+			Sleeping: instance[mongo.StateKey] == types.AppSleeping,
 		}
-		appBindingJSON, err := json.Marshal(appBind)
-		if err != nil {
+		if err := redis.RefreshApp(name, appBind); err != nil {
 			utils.LogError("Master-Discovery-1", err)
-			continue
 		}
-		payload[instance[mongo.NameKey].(string)] = appBindingJSON
-	}
-	if err := redis.BulkRegisterApps(payload); err != nil {
-		utils.LogError("Master-Discovery-2", err)
 	}
 }
 
@@ -79,6 +110,63 @@ func registerDatabases(instances []types.M, currentIP string, config *configs.Ge
 	}
 }
 
+// registerCapacity reports the resources of a worker node along with the resources
+// which are not requested by the applications and replicas deployed on it
+func registerCapacity(currentIP string, config *configs.GenericService) {
+	cpu, memory, err := docker.InspectHostResources()
+	if err != nil {
+		utils.LogError("Master-Discovery-6", err)
+		return
+	}
+	capacity := &types.NodeCapacity{
+		CPU:               cpu,
+		Memory:            memory,
+		AllocatableCPU:    cpu,
+		AllocatableMemory: memory,
+	}
+
+	apps, err := mongo.FetchResources(mongo.InstanceCollection, types.M{
+		mongo.HostIPKey:       currentIP,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	})
+	if err != nil {
+		utils.LogError("Master-Discovery-7", err)
+		return
+	}
+	replicas, err := mongo.FetchResources(mongo.ReplicaCollection, types.M{
+		mongo.HostIPKey: currentIP,
+	})
+	if err != nil {
+		utils.LogError("Master-Discovery-8", err)
+		return
+	}
+	for _, resources := range append(apps, replicas...) {
+		capacity.Allocate(resources)
+	}
+
+	// The resources reserved for the applications and replicas placed on the node
+	// are released once they have been stored
+	appNames, err := mongo.FetchNames(mongo.InstanceCollection, types.M{
+		mongo.HostIPKey:       currentIP,
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	})
+	if err != nil {
+		utils.LogError("Master-Discovery-11", err)
+		return
+	}
+	replicaNames, err := mongo.FetchNames(mongo.ReplicaCollection, types.M{
+		mongo.HostIPKey: currentIP,
+	})
+	if err != nil {
+		utils.LogError("Master-Discovery-12", err)
+		return
+	}
+
+	if err := redis.RegisterNodeCapacity(fmt.Sprintf("%s:%d", currentIP, config.Port), capacity, append(appNames, replicaNames...)); err != nil {
+		utils.LogError("Master-Discovery-9", err)
+	}
+}
+
 // exposeService exposes a single microservice along with its apps
 func exposeService(service, currentIP string, config *configs.GenericService) {
 	count := 0
@@ -87,6 +175,10 @@ func exposeService(service, currentIP string, config *configs.GenericService) {
 		instances = instanceServiceBindings[service](currentIP, service)
 		count = len(instances)
 	}
+	// Replicas of applications deployed on the node add to its load as well
+	if service == types.AppMaker {
+		count += len(mongo.FetchReplicas(types.M{mongo.HostIPKey: currentIP}))
+	}
 	err := redis.RegisterService(
 		service,
 		fmt.Sprintf("%s:%d", currentIP, config.Port),
@@ -99,6 +191,9 @@ func exposeService(service, currentIP string, config *configs.GenericService) {
 	if instanceRegistrationBindings[service] != nil {
 		instanceRegistrationBindings[service](instances, currentIP, config)
 	}
+	if service == types.AppMaker {
+		registerCapacity(currentIP, config)
+	}
 }
 
 // exposeServices exposes the microservices running on a host machine for discovery
@@ -108,6 +203,12 @@ func exposeServices() {
 		return
 	}
 	checkAndUpdateState(currIP)
+	if err := redis.RegisterNodeLabels(currIP, configs.GasperConfig.Labels); err != nil {
+		utils.LogError("Master-Discovery-10", err)
+	}
+	// The heartbeat is sent before the microservices are exposed so that
+	// they are not considered dead by Master right after being exposed
+	sendHeartbeat(currIP)
 	for service, config := range configs.ServiceMap {
 		if config.Deploy {
 			go exposeService(service, currIP, config)
@@ -0,0 +1,102 @@
+package master
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/docker"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+// heartbeatMisses is the number of heartbeats a node can miss before it is considered dead
+const heartbeatMisses = 3
+
+// unhealthyBeats holds the number of consecutive heartbeats in which the microservices
+// of the current node have been unhealthy
+var (
+	unhealthyBeats      = make(map[string]int)
+	unhealthyBeatsMutex sync.Mutex
+)
+
+// dockerServices are the microservices which depend on the docker daemon of their node
+var dockerServices = []string{
+	types.AppMaker,
+	types.MySQL,
+	types.MongoDB,
+	types.PostgreSQL,
+	types.Redis,
+}
+
+// heartbeatTTL returns the duration after which the heartbeat of a node expires
+func heartbeatTTL() time.Duration {
+	return heartbeatMisses * configs.ServiceConfig.ExposureInterval * time.Second
+}
+
+// checkServiceHealth checks whether a microservice running on the current node accepts requests
+// and whether the docker daemon it depends on is responding
+func checkServiceHealth(service, currentIP string, config *configs.GenericService) types.ServiceHealth {
+	health := types.ServiceHealth{
+		Instance: fmt.Sprintf("%s:%d", currentIP, config.Port),
+		Healthy:  true,
+	}
+	local := fmt.Sprintf("127.0.0.1:%d", config.Port)
+
+	// Handle GenDNS's health-check by sending a UDP probe instead of TCP
+	if service == types.GenDNS {
+		if !utils.IsGenDNSAlive(local) {
+			health.Healthy = false
+			health.Detail = "DNS probe failed"
+		}
+		return health
+	}
+	conn, err := net.DialTimeout("tcp", local, 5*time.Second)
+	if err != nil {
+		health.Healthy = false
+		health.Detail = err.Error()
+		return health
+	}
+	conn.Close()
+
+	if utils.Contains(dockerServices, service) {
+		if err := docker.Ping(); err != nil {
+			health.Healthy = false
+			health.Detail = fmt.Sprintf("Docker daemon is not responding: %s", err.Error())
+		}
+	}
+	return health
+}
+
+// sendHeartbeat registers the heartbeat of the current node along with the health of its microservices
+func sendHeartbeat(currentIP string) {
+	services := make(map[string]types.ServiceHealth)
+	unhealthyBeatsMutex.Lock()
+	for service, config := range configs.ServiceMap {
+		if config.Deploy {
+			health := checkServiceHealth(service, currentIP, config)
+			if health.Healthy {
+				unhealthyBeats[service] = 0
+			} else {
+				unhealthyBeats[service]++
+			}
+			health.UnhealthyBeats = unhealthyBeats[service]
+			services[service] = health
+		}
+	}
+	unhealthyBeatsMutex.Unlock()
+	now := time.Now()
+	ttl := heartbeatTTL()
+	err := redis.RegisterHeartbeat(&types.Heartbeat{
+		HostIP:    currentIP,
+		LastSeen:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		Services:  services,
+	}, ttl)
+	if err != nil {
+		utils.LogError("Master-Heartbeat-1", err)
+	}
+}
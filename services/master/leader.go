@@ -0,0 +1,57 @@
+package master
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+)
+
+const (
+	// leaderLease is the duration after which the leadership of a Master instance
+	// which stopped renewing it expires, letting another Master instance take over
+	leaderLease = 15 * time.Second
+
+	// leaderRenewInterval is the interval in which Master instances campaign for the leadership
+	leaderRenewInterval = 5 * time.Second
+)
+
+// leading is set to 1 while the current Master instance is the leader
+var leading int32
+
+// currentMaster returns the URL of the Master instance on the current node
+func currentMaster() string {
+	return fmt.Sprintf("%s:%d", utils.HostIP, configs.ServiceConfig.Master.Port)
+}
+
+// IsLeader returns whether the current Master instance is the leader
+// Only the leader removes dead instances and reschedules applications
+func IsLeader() bool {
+	return atomic.LoadInt32(&leading) == 1
+}
+
+// campaign acquires or renews the leadership for the current Master instance
+func campaign() {
+	candidate := currentMaster()
+	leader, err := redis.AcquireLeadership(candidate, leaderLease)
+	if err != nil {
+		utils.LogError("Master-Leader-1", err)
+		leader = false
+	}
+	if leader && atomic.CompareAndSwapInt32(&leading, 0, 1) {
+		utils.LogInfo("Master-Leader-2", "%s became the leader", candidate)
+	}
+	if !leader && atomic.CompareAndSwapInt32(&leading, 1, 0) {
+		utils.LogInfo("Master-Leader-3", "%s is no longer the leader", candidate)
+	}
+}
+
+// ScheduleLeaderElection campaigns for the leadership on given intervals of time
+func ScheduleLeaderElection() {
+	campaign()
+	scheduler := utils.NewScheduler(leaderRenewInterval, campaign)
+	scheduler.RunAsync()
+}
@@ -68,3 +68,40 @@ func IsAppOwner(c *gin.Context) {
 func IsDatabaseOwner(c *gin.Context) {
 	isInstanceOwner(c, mongo.DBInstance, dbReqParam)
 }
+
+// IsDeployKeyOwner checks if a user is entitled to manage the deploy key of an application
+// Deploy keys can be managed before the application is created as the key must be added
+// to the git repository beforehand, in that case the user who created the key owns it
+func IsDeployKeyOwner(c *gin.Context) {
+	count, err := mongo.CountInstances(types.M{
+		mongo.NameKey:         c.Param(appReqParam),
+		mongo.InstanceTypeKey: mongo.AppInstance,
+	})
+	if err != nil {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if count != 0 {
+		IsAppOwner(c)
+		return
+	}
+
+	user := ExtractClaims(c)
+	if user == nil {
+		utils.SendServerErrorResponse(c, errors.New("Failed to extract JWT claims"))
+		return
+	}
+	key, err := mongo.FetchDeployKey(c.Param(appReqParam))
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SendServerErrorResponse(c, err)
+		return
+	}
+	if key != nil && key.Owner != user.GetEmail() && !user.IsAdmin() {
+		c.AbortWithStatusJSON(401, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("User %s is not entitled to manage the deploy key of application %s", user.GetEmail(), c.Param(appReqParam)),
+		})
+		return
+	}
+	c.Next()
+}
@@ -2,10 +2,13 @@ package middlewares
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	validator "github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
+	"github.com/sdslabs/gasper/lib/git"
 	"github.com/sdslabs/gasper/lib/mongo"
 	"github.com/sdslabs/gasper/lib/utils"
 	"github.com/sdslabs/gasper/types"
@@ -22,6 +25,9 @@ var disallowedApplicationNames = []string{
 	types.GenSSH,
 }
 
+// invalidRefCharacters are the characters which cannot be present in a git reference
+const invalidRefCharacters = " \t\n~^:?*[\\"
+
 var disallowedDatabaseNames = []string{
 	"admin",
 	"config",
@@ -44,6 +50,31 @@ func isUniqueInstance(instanceName, instanceType string) (bool, error) {
 	return true, nil
 }
 
+// ValidateGit checks whether the git repository of an application along with the
+// branch, reference and commit it is deployed from are valid
+func ValidateGit(repo *types.Git) error {
+	if result, err := validator.ValidateStruct(repo); !result {
+		return err
+	}
+	if !validator.IsURL(repo.RepoURL) && !git.IsSSHURL(repo.RepoURL) {
+		return errors.New("Field 'repo_url' inside field 'git' is not a valid URL")
+	}
+	if repo.Ref != "" && repo.Branch != "" {
+		return errors.New("Fields 'branch' and 'ref' inside field 'git' cannot be provided together")
+	}
+	if strings.ContainsAny(repo.Ref, invalidRefCharacters) || strings.Contains(repo.Ref, "..") {
+		return errors.New("Field 'ref' inside field 'git' is not a valid git reference")
+	}
+	// The validators of govalidator reject empty strings hence the commit is checked only if it is provided
+	if repo.Commit != "" && !validator.IsHexadecimal(repo.Commit) {
+		return errors.New("Field 'commit' inside field 'git' should be a hexadecimal commit SHA")
+	}
+	if repo.Commit != "" && len(repo.Commit) != 40 {
+		return errors.New("Field 'commit' inside field 'git' should be a complete commit SHA of 40 characters")
+	}
+	return nil
+}
+
 // ValidateApplicationRequest validates the request for creating applications
 func ValidateApplicationRequest(c *gin.Context) {
 	requestBody := getBodyFromContext(c)
@@ -66,6 +97,65 @@ func ValidateApplicationRequest(c *gin.Context) {
 		return
 	}
 
+	// Applications built from a Dockerfile define their own entrypoint
+	if app.GetIndex() == "" && c.Param("language") != types.Docker {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   "Field 'index' inside field 'context' was required but was not provided",
+		})
+		return
+	}
+
+	if err := ValidateGit(&app.Git); err != nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// An application is deployed as a single replica if the number of replicas isn't provided
+	// hence it is validated only if it is present in the request
+	replicas := &struct {
+		Replicas *int `json:"replicas"`
+	}{}
+	json.Unmarshal(requestBody, replicas)
+	if replicas.Replicas != nil && (*replicas.Replicas < 1 || *replicas.Replicas > types.MaxReplicas) {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Field 'replicas' should be between 1 and %d", types.MaxReplicas),
+		})
+		return
+	}
+
+	if app.HTTPS != nil {
+		if err := app.HTTPS.Validate(); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	if app.Access != nil {
+		if err := app.Access.Validate(); err != nil {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	if app.IdleTimeout < 0 || (app.IdleTimeout > 0 && app.IdleTimeout < types.MinIdleTimeout) {
+		c.AbortWithStatusJSON(400, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("Field 'idle_timeout' should be 0 or at least %d minutes", types.MinIdleTimeout),
+		})
+		return
+	}
+
 	if utils.Contains(disallowedApplicationNames, app.GetName()) {
 		c.AbortWithStatusJSON(400, gin.H{
 			"success": false,
@@ -87,6 +177,29 @@ func ValidateApplicationRequest(c *gin.Context) {
 		})
 		return
 	}
+
+	// Repositories accessed over SSH require a deploy key which has been added to the git host
+	if git.IsSSHURL(app.GetGitRepositoryURL()) {
+		key, err := mongo.FetchDeployKey(app.GetName())
+		if err == mongo.ErrNoDocuments {
+			c.AbortWithStatusJSON(400, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Deploy key for application %s must be created before using an SSH repository URL", app.GetName()),
+			})
+			return
+		}
+		if err != nil {
+			utils.SendServerErrorResponse(c, err)
+			return
+		}
+		if user := ExtractClaims(c); user == nil || key.Owner != user.GetEmail() {
+			c.AbortWithStatusJSON(401, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("Deploy key for application %s belongs to another user", app.GetName()),
+			})
+			return
+		}
+	}
 	c.Next()
 }
 
@@ -0,0 +1,256 @@
+package placement
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sdslabs/gasper/configs"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// Spread places applications on the worker nodes having the most resources available
+	// so that the load is distributed evenly among all worker nodes
+	Spread = "spread"
+
+	// BinPack places applications on the worker nodes having the least resources available
+	// which can still hold them so that the worker nodes are utilized to their capacity
+	BinPack = "binpack"
+
+	// reservationTTL is the duration after which the resources reserved for an instance which
+	// was never stored on its worker node are released
+	reservationTTL = 30 * time.Minute
+)
+
+var (
+	// ErrNoWorkers is the error when no worker instances are available
+	ErrNoWorkers = errors.New("No worker instances available at the moment")
+
+	// ErrInsufficientResources is the error when the requested resources fit on no worker instance
+	ErrInsufficientResources = errors.New("No worker instance has enough resources available at the moment")
+
+	// ErrUnsatisfiable is the error when no instance satisfies the placement constraints
+	ErrUnsatisfiable = errors.New("No instance satisfies the placement constraints at the moment")
+)
+
+// candidate is a worker node which can hold the requested resources
+type candidate struct {
+	url      string
+	labels   map[string]string
+	capacity *types.NodeCapacity
+}
+
+// hostIP returns the IP address of the node of an instance
+func hostIP(instance string) string {
+	return strings.Split(instance, ":")[0]
+}
+
+// NodeLabels returns the labels of all nodes mapped to their IP addresses
+// Labels attached by admins take precedence over the ones defined in the configuration of the nodes
+func NodeLabels() (map[string]map[string]string, error) {
+	nodeLabels, err := redis.FetchNodeLabels()
+	if err != nil {
+		return nil, err
+	}
+	attachedLabels, err := mongo.FetchNodeLabels()
+	if err != nil {
+		return nil, err
+	}
+	for node, labels := range attachedLabels {
+		if nodeLabels[node] == nil {
+			nodeLabels[node] = make(map[string]string)
+		}
+		for key, value := range labels {
+			nodeLabels[node][key] = value
+		}
+	}
+	return nodeLabels, nil
+}
+
+// schedulableInstances returns the instances of a service which are not present on cordoned nodes
+// sorted in the increasing order of their load
+func schedulableInstances(service string) ([]string, error) {
+	instances, err := redis.FetchServiceInstances(service)
+	if err != nil {
+		return nil, err
+	}
+	cordoned, err := redis.FetchCordonedNodes()
+	if err != nil {
+		return nil, err
+	}
+	schedulable := make([]string, 0)
+	for _, instance := range instances {
+		if !utils.Contains(cordoned, hostIP(instance)) {
+			schedulable = append(schedulable, instance)
+		}
+	}
+	if len(schedulable) == 0 {
+		return nil, ErrNoWorkers
+	}
+	return schedulable, nil
+}
+
+// antiAffinity keeps track of the values of the anti-affinity labels taken by the
+// nodes holding the replicas of an application
+type antiAffinity struct {
+	keys  []string
+	taken map[string]map[string]bool
+}
+
+// newAntiAffinity returns the anti-affinity of the given label keys among the peers
+func newAntiAffinity(keys []string, peers []string, nodeLabels map[string]map[string]string) *antiAffinity {
+	affinity := &antiAffinity{
+		keys:  keys,
+		taken: make(map[string]map[string]bool),
+	}
+	for _, key := range keys {
+		affinity.taken[key] = make(map[string]bool)
+	}
+	for _, peer := range peers {
+		affinity.take(nodeLabels[hostIP(peer)])
+	}
+	return affinity
+}
+
+// take marks the values of the anti-affinity labels of a node as taken
+func (affinity *antiAffinity) take(labels map[string]string) {
+	for _, key := range affinity.keys {
+		if value, ok := labels[key]; ok {
+			affinity.taken[key][value] = true
+		}
+	}
+}
+
+// allows returns whether a node having the given labels has values of the anti-affinity labels
+// which are not taken, nodes which don't have an anti-affinity label are not allowed
+func (affinity *antiAffinity) allows(labels map[string]string) bool {
+	for _, key := range affinity.keys {
+		value, ok := labels[key]
+		if !ok || affinity.taken[key][value] {
+			return false
+		}
+	}
+	return true
+}
+
+// selectWorkers selects at most count distinct worker instances satisfying the placement constraints
+// on which the requested resources can be allocated, along with the number of worker instances
+// which satisfy the node selector irrespective of their resources
+// Excluded worker instances are left out without taking part in the anti-affinity among the peers
+func selectWorkers(name string, resources types.Resources, constraints types.Placement, count int, peers, excluded []string) ([]string, int, error) {
+	// Worker instances are sorted in the increasing order of the number of applications deployed
+	// which breaks the ties between instances with equal resources available
+	workers, err := schedulableInstances(redis.WorkerInstanceKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	capacities, err := redis.FetchNodeCapacities()
+	if err != nil {
+		return nil, 0, err
+	}
+	nodeLabels, err := NodeLabels()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := 0
+	candidates := make([]candidate, 0)
+	for _, worker := range workers {
+		if utils.Contains(peers, worker) || utils.Contains(excluded, worker) {
+			continue
+		}
+		labels := nodeLabels[hostIP(worker)]
+		if !constraints.Matches(labels) {
+			continue
+		}
+		matched++
+		// Worker instances which haven't reported their capacity yet are not scheduled on
+		capacity, ok := capacities[worker]
+		if !ok || !capacity.Fits(resources) {
+			continue
+		}
+		candidates = append(candidates, candidate{url: worker, labels: labels, capacity: capacity})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if configs.ServiceConfig.Master.SchedulingStrategy == BinPack {
+			return candidates[i].capacity.Availability() < candidates[j].capacity.Availability()
+		}
+		return candidates[i].capacity.Availability() > candidates[j].capacity.Availability()
+	})
+
+	affinity := newAntiAffinity(constraints.AntiAffinity, peers, nodeLabels)
+	selected := make([]string, 0)
+	for _, node := range candidates {
+		if len(selected) == count {
+			break
+		}
+		if !affinity.allows(node.labels) {
+			continue
+		}
+		// The resources are reserved atomically as instances are placed concurrently, the node
+		// is skipped if the resources have been reserved by another placement in the meantime
+		reserved, err := redis.ReserveNodeCapacity(node.url, name, resources, reservationTTL)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !reserved {
+			continue
+		}
+		affinity.take(node.labels)
+		selected = append(selected, node.url)
+	}
+	return selected, matched, nil
+}
+
+// Workers returns at most count distinct worker instances satisfying the placement constraints on which
+// the requested resources can be allocated, ordered by the configured scheduling strategy
+// Peers are the worker instances holding the other replicas of the application, they are left out along
+// with the worker instances sharing the values of the anti-affinity labels with them and cordoned nodes
+// The resources are reserved for the named application on the returned instances until the application
+// or its replica is stored on them
+func Workers(name string, resources types.Resources, constraints types.Placement, count int, peers []string) ([]string, error) {
+	selected, _, err := selectWorkers(name, resources, constraints, count, peers, nil)
+	return selected, err
+}
+
+// Worker returns a single worker instance satisfying the placement constraints on which
+// the requested resources can be allocated
+// Excluded worker instances are left out as well but unlike the peers their labels don't restrict the placement
+func Worker(name string, resources types.Resources, constraints types.Placement, peers, excluded []string) (string, error) {
+	selected, matched, err := selectWorkers(name, resources, constraints, 1, peers, excluded)
+	if err != nil {
+		return "", err
+	}
+	if len(selected) == 0 {
+		if matched == 0 {
+			return "", ErrUnsatisfiable
+		}
+		return "", ErrInsufficientResources
+	}
+	return selected[0], nil
+}
+
+// Instance returns the least loaded instance of a service whose node satisfies the node selector
+// and is not cordoned
+func Instance(service string, constraints types.Placement) (string, error) {
+	instances, err := schedulableInstances(service)
+	if err != nil {
+		return "", err
+	}
+	nodeLabels, err := NodeLabels()
+	if err != nil {
+		return "", err
+	}
+	for _, instance := range instances {
+		if constraints.Matches(nodeLabels[hostIP(instance)]) {
+			return instance, nil
+		}
+	}
+	return "", ErrUnsatisfiable
+}
@@ -0,0 +1,159 @@
+package master
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/sdslabs/gasper/lib/factory"
+	"github.com/sdslabs/gasper/lib/mongo"
+	"github.com/sdslabs/gasper/lib/redis"
+	"github.com/sdslabs/gasper/lib/utils"
+	"github.com/sdslabs/gasper/services/master/placement"
+	"github.com/sdslabs/gasper/types"
+)
+
+const (
+	// reschedulePollInterval is the interval in which every rescheduling worker looks for due jobs
+	reschedulePollInterval = 5 * time.Second
+
+	// rescheduleLease is the duration for which a claimed job is hidden from other workers
+	// It must be longer than the time taken for creating an application via gRPC
+	rescheduleLease = 5 * time.Minute
+
+	// rescheduleBackoff is the delay before the second attempt of a job which doubles with every attempt
+	rescheduleBackoff = 30 * time.Second
+
+	// maxRescheduleBackoff is the maximum delay between two attempts of a job
+	maxRescheduleBackoff = 10 * time.Minute
+
+	// maxRescheduleAttempts is the number of attempts after which a job is marked as stuck
+	maxRescheduleAttempts = 5
+)
+
+// rescheduleDelay returns the delay before the next attempt of a job which has failed the given number of times
+func rescheduleDelay(attempts int) time.Duration {
+	delay := rescheduleBackoff
+	for i := 1; i < attempts && delay < maxRescheduleBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRescheduleBackoff {
+		return maxRescheduleBackoff
+	}
+	return delay
+}
+
+// enqueueApplications adds jobs for rescheduling the applications present on a lost node to the rescheduling queue
+func enqueueApplications(apps []types.M, instanceIP string) {
+	now := time.Now().Unix()
+	for _, app := range apps {
+		name, ok := app[mongo.NameKey].(string)
+		// Stopped applications stay on their node until they are started again
+		if !ok || app[mongo.StateKey] == types.AppStopped {
+			continue
+		}
+		err := redis.EnqueueRescheduleJob(&types.RescheduleJob{
+			Name:          name,
+			HostIP:        instanceIP,
+			State:         types.RescheduleQueued,
+			FailedNodes:   []string{},
+			EnqueuedAt:    now,
+			NextAttemptAt: now,
+		})
+		if err != nil {
+			utils.LogError("Master-Rescheduler-1", err)
+		}
+	}
+}
+
+// rescheduleApplication re-deploys the application of a job on a worker node having enough resources
+// available for it, worker nodes on which the previous attempts of the job failed are avoided
+// The application is deployed at the commit and with the lifecycle state it had on the lost node
+func rescheduleApplication(job *types.RescheduleJob) error {
+	apps := mongo.FetchAppInfo(types.M{
+		mongo.NameKey: job.Name,
+	})
+	// The application has been deleted, moved to another node or stopped since the job was enqueued
+	if len(apps) == 0 || apps[0][mongo.HostIPKey] != job.HostIP || apps[0][mongo.StateKey] == types.AppStopped {
+		return nil
+	}
+	appConfig, err := mongo.FetchSingleApp(job.Name)
+	if err != nil {
+		return err
+	}
+
+	// Worker nodes holding replicas of the application cannot hold the application as well
+	// while the worker nodes on which the previous attempts failed are only left out
+	peers := make([]string, 0)
+	for _, replica := range mongo.FetchReplicas(types.M{mongo.NameKey: job.Name}) {
+		if node, ok := replica[mongo.NodeKey].(string); ok {
+			peers = append(peers, node)
+		}
+	}
+	instanceURL, err := placement.Worker(job.Name, appConfig.Resources, appConfig.Placement, peers, job.FailedNodes)
+	if err != nil {
+		// Every worker node is considered again once none of the remaining ones can hold the application
+		job.FailedNodes = []string{}
+		return err
+	}
+	utils.LogInfo("Master-Rescheduler-2", "Re-scheduling application %s to %s", job.Name, instanceURL)
+
+	if _, err := factory.RelocateApplication(job.Name, instanceURL); err != nil {
+		job.FailedNodes = append(job.FailedNodes, instanceURL)
+		return err
+	}
+	return nil
+}
+
+// processRescheduleJob attempts a job and either removes it if it succeeded
+// or queues it for its next attempt with a backoff
+func processRescheduleJob(job *types.RescheduleJob) {
+	err := rescheduleApplication(job)
+	if err == nil {
+		if err := redis.RemoveRescheduleJob(job.Name); err != nil {
+			utils.LogError("Master-Rescheduler-3", err)
+		}
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+	if job.Attempts >= maxRescheduleAttempts {
+		job.State = types.RescheduleStuck
+		utils.LogError("Master-Rescheduler-4", fmt.Errorf(
+			"Application %s could not be re-scheduled after %d attempts: %s", job.Name, job.Attempts, job.LastError))
+	} else {
+		job.State = types.RescheduleQueued
+		job.NextAttemptAt = time.Now().Add(rescheduleDelay(job.Attempts)).Unix()
+		utils.LogError("Master-Rescheduler-5", fmt.Errorf(
+			"Application %s could not be re-scheduled, retrying later: %s", job.Name, job.LastError))
+	}
+	if err := redis.RegisterRescheduleJob(job); err != nil {
+		utils.LogError("Master-Rescheduler-6", err)
+	}
+}
+
+// processRescheduleQueue attempts the due jobs in the rescheduling queue one at a time
+// while the current Master instance is the leader
+func processRescheduleQueue() {
+	for IsLeader() {
+		job, err := redis.ClaimRescheduleJob(rescheduleLease)
+		if err != nil {
+			utils.LogError("Master-Rescheduler-7", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		processRescheduleJob(job)
+	}
+}
+
+// ScheduleRescheduling starts a fixed number of workers, equal to the number of logical CPUs,
+// which re-deploy the applications present on lost nodes from the rescheduling queue
+func ScheduleRescheduling() {
+	for i := 0; i < runtime.NumCPU(); i++ {
+		scheduler := utils.NewScheduler(reschedulePollInterval, processRescheduleQueue)
+		scheduler.RunAsync()
+	}
+}
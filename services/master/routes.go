@@ -67,12 +67,42 @@ func NewService() http.Handler {
 		app.PUT("/:app", m.IsAppOwner, c.UpdateAppByName)
 		app.DELETE("/:app", m.IsAppOwner, c.DeleteApp)
 		app.GET("/:app/logs", m.IsAppOwner, c.FetchAppLogs)
+		app.GET("/:app/builds", m.IsAppOwner, c.FetchAppBuilds)
+		app.GET("/:app/builds/:id/logs", m.IsAppOwner, c.FetchAppBuildLogs)
 		app.PATCH("/:app/rebuild", m.IsAppOwner, c.RebuildApp)
+		app.PATCH("/:app/start", m.IsAppOwner, c.StartApp)
+		app.PATCH("/:app/stop", m.IsAppOwner, c.StopApp)
+		app.PATCH("/:app/restart", m.IsAppOwner, c.RestartApp)
+		app.GET("/:app/releases", m.IsAppOwner, c.FetchAppReleases)
+		app.PATCH("/:app/rollback/:release", m.IsAppOwner, c.RollbackApp)
+		app.PATCH("/:app/scale", m.IsAppOwner, c.ScaleApp)
 		app.PATCH("/:app/transfer/:user", m.IsAppOwner, c.TransferApplicationOwnership)
 		app.GET("/:app/term", m.IsAppOwner, c.DeployWebTerminal)
 		app.GET("/:app/metrics", c.FetchMetrics)
+		app.GET("/:app/traffic", m.IsAppOwner, c.FetchTraffic)
+		app.PUT("/:app/webhook", m.IsAppOwner, c.RotateAppWebhookSecret)
+		app.DELETE("/:app/webhook", m.IsAppOwner, c.DisableAppWebhook)
+		app.GET("/:app/webhook/deliveries", m.IsAppOwner, c.FetchAppWebhookDeliveries)
+		app.PUT("/:app/deploy_key", m.IsDeployKeyOwner, c.CreateDeployKey)
+		app.GET("/:app/deploy_key", m.IsDeployKeyOwner, c.FetchDeployKey)
+		app.DELETE("/:app/deploy_key", m.IsDeployKeyOwner, c.DeleteDeployKey)
+		app.PUT("/:app/domains", m.IsAppOwner, c.AttachDomain)
+		app.GET("/:app/domains", m.IsAppOwner, c.FetchDomains)
+		app.PATCH("/:app/domains/:domain/verify", m.IsAppOwner, c.VerifyDomain)
+		app.DELETE("/:app/domains/:domain", m.IsAppOwner, c.DetachDomain)
+		app.PUT("/:app/https", m.IsAppOwner, c.SetHTTPSPolicy)
+		app.PUT("/:app/routes", m.IsAppOwner, c.AddRoute)
+		app.GET("/:app/routes", m.IsAppOwner, c.FetchRoutes)
+		app.DELETE("/:app/routes", m.IsAppOwner, c.DeleteRoute)
+		app.DELETE("/:app/https", m.IsAppOwner, c.ResetHTTPSPolicy)
+		app.PUT("/:app/access", m.IsAppOwner, c.SetAccessPolicy)
+		app.GET("/:app/access", m.IsAppOwner, c.FetchAccessPolicy)
+		app.DELETE("/:app/access", m.IsAppOwner, c.ResetAccessPolicy)
 	}
 
+	// Webhooks are authenticated with the application's webhook secret instead of a JSON Web Token
+	router.POST("/webhooks/:app", c.HandleAppWebhook)
+
 	db := router.Group("/dbs")
 	db.Use(m.AuthRequired())
 	{
@@ -114,10 +144,25 @@ func NewService() http.Handler {
 			users.PATCH("/:user/grant", c.GrantSuperuserPrivilege)
 			users.PATCH("/:user/revoke", c.RevokeSuperuserPrivilege)
 		}
+		reschedules := admin.Group("/reschedules")
+		{
+			reschedules.GET("", c.GetRescheduleJobs)
+			reschedules.PATCH("/:app/retry", c.RetryRescheduleJob)
+			reschedules.DELETE("/:app", c.DeleteRescheduleJob)
+		}
+		reconcile := admin.Group("/reconcile")
+		{
+			reconcile.GET("/:node", c.GetReconcileReport)
+		}
 		nodes := admin.Group("/nodes")
 		{
 			nodes.GET("", c.GetAllNodes)
 			nodes.GET("/:type", c.GetNodesByName)
+			nodes.PUT("/:node/labels", c.AttachNodeLabels)
+			nodes.DELETE("/:node/labels", c.DetachNodeLabels)
+			nodes.PATCH("/:node/cordon", c.CordonNode)
+			nodes.PATCH("/:node/uncordon", c.UncordonNode)
+			nodes.POST("/:node/drain", c.DrainNode)
 		}
 	}
 
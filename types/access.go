@@ -0,0 +1,101 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	// AccessDenied is the reason of rejecting a request from a client IP not permitted by the IP lists of an application
+	AccessDenied = "denied"
+
+	// AccessRateLimited is the reason of rejecting a request from a client IP which exceeded the rate limit of an application
+	AccessRateLimited = "rate_limited"
+
+	// defaultRateLimitPeriod is the period (in seconds) of a rate limit when none is specified
+	defaultRateLimitPeriod = 60
+)
+
+// RateLimit defines the token bucket limiting the requests of a client IP to an application
+// The bucket holds up to Burst tokens and is refilled with Requests tokens every Period seconds
+type RateLimit struct {
+	Requests int   `json:"requests" bson:"requests"`
+	Period   int64 `json:"period,omitempty" bson:"period,omitempty"`
+	Burst    int   `json:"burst,omitempty" bson:"burst,omitempty"`
+}
+
+// GetPeriod returns the period (in seconds) in which the bucket is refilled with Requests tokens
+func (limit *RateLimit) GetPeriod() int64 {
+	if limit.Period <= 0 {
+		return defaultRateLimitPeriod
+	}
+	return limit.Period
+}
+
+// Capacity returns the number of tokens the bucket can hold, which defaults to Requests
+func (limit *RateLimit) Capacity() int {
+	if limit.Burst <= 0 {
+		return limit.Requests
+	}
+	return limit.Burst
+}
+
+// Rate returns the number of tokens added to the bucket every second
+func (limit *RateLimit) Rate() float64 {
+	return float64(limit.Requests) / float64(limit.GetPeriod())
+}
+
+// AccessPolicy defines which client IPs can send requests to an application and how many
+// IPs are matched against the deny list first, if the allow list isn't empty only the IPs on it are permitted
+type AccessPolicy struct {
+	RateLimit *RateLimit `json:"rate_limit,omitempty" bson:"rate_limit,omitempty"`
+	Allow     []string   `json:"allow,omitempty" bson:"allow,omitempty"`
+	Deny      []string   `json:"deny,omitempty" bson:"deny,omitempty"`
+}
+
+// Validate checks whether the access policy is well formed
+func (policy *AccessPolicy) Validate() error {
+	if policy.RateLimit != nil {
+		if policy.RateLimit.Requests <= 0 {
+			return fmt.Errorf("Field 'requests' inside field 'rate_limit' should be greater than 0")
+		}
+		if policy.RateLimit.Period < 0 || policy.RateLimit.Burst < 0 {
+			return fmt.Errorf("Fields 'period' and 'burst' inside field 'rate_limit' cannot be negative")
+		}
+	}
+	if _, err := ParseCIDRs(policy.Allow); err != nil {
+		return err
+	}
+	if _, err := ParseCIDRs(policy.Deny); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseCIDRs parses a list of CIDR ranges, a bare IP address is treated as the range holding only that address
+func ParseCIDRs(list []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(list))
+	for _, entry := range list {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("%s is neither an IP address nor a CIDR range", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%s is neither an IP address nor a CIDR range", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"math"
+	"strings"
+	"time"
 )
 
 // Application is the interface for creating an application
@@ -10,6 +12,9 @@ type Application interface {
 	GetName() string
 	GetGitRepositoryURL() string
 	GetGitRepositoryBranch() string
+	GetGitReference() string
+	GetGitCommit() string
+	IsGitPinned() bool
 	HasGitAccessToken() bool
 	GetGitAccessToken() string
 	GetIndex() string
@@ -22,28 +27,39 @@ type Application interface {
 	GetEnvVars() map[string]interface{}
 	GetNameServers() []string
 	GetDockerImage() string
+	SetDockerImage(image string)
+	GetDockerfile() string
 	SetContainerID(id string)
 	GetContainerID() string
 	SetContainerPort(port int)
 	GetContainerPort() int
 	HasConfGenerator() bool
 	InvokeConfGenerator(name, index string) string
+	SetState(state string)
+	GetState() string
+	SetCommit(commit string)
+	GetCommit() string
 }
 
 // Git stores the information related to the application's git repository
 type Git struct {
-	RepoURL     string `json:"repo_url" bson:"repo_url" valid:"required~Field 'repo_url' inside field 'git' is required but was not provided,url~Field 'repo_url' inside field 'git' is not a valid URL"`
+	RepoURL     string `json:"repo_url" bson:"repo_url" valid:"required~Field 'repo_url' inside field 'git' is required but was not provided"`
 	AccessToken string `json:"access_token,omitempty" bson:"access_token,omitempty"`
 	Branch      string `json:"branch,omitempty" bson:"branch,omitempty"`
+	// Ref is the tag (or the complete git reference) the application is pinned to
+	Ref string `json:"ref,omitempty" bson:"ref,omitempty"`
+	// Commit is the hash of the commit the application is pinned to
+	Commit string `json:"commit,omitempty" bson:"commit,omitempty"`
 }
 
 // Context stores the information related to building and running an application
 type Context struct {
-	Index  string   `json:"index" bson:"index" valid:"required~Field 'index' inside field 'context' was required but was not provided"`
-	Port   int      `json:"port" bson:"port" valid:"port~Field 'port' inside field 'context' is not a valid port"`
-	RcFile bool     `json:"rc_file" bson:"rc_file"`
-	Build  []string `json:"build,omitempty" bson:"build,omitempty"`
-	Run    []string `json:"run,omitempty" bson:"run,omitempty"`
+	Index      string   `json:"index" bson:"index"`
+	Port       int      `json:"port" bson:"port" valid:"port~Field 'port' inside field 'context' is not a valid port"`
+	RcFile     bool     `json:"rc_file" bson:"rc_file"`
+	Build      []string `json:"build,omitempty" bson:"build,omitempty"`
+	Run        []string `json:"run,omitempty" bson:"run,omitempty"`
+	Dockerfile string   `json:"dockerfile,omitempty" bson:"dockerfile,omitempty"`
 }
 
 // Resources defines the resources requested by an application
@@ -55,6 +71,22 @@ type Resources struct {
 	CPU float64 `json:"cpu" bson:"cpu" valid:"float~Field 'cpu' inside field 'resources' should be of type float"`
 }
 
+// GetCPU returns the CPU quota in units of CPUs, the default quota is returned if none was requested
+func (resources Resources) GetCPU() float64 {
+	if resources.CPU == 0 {
+		return DefaultCPUs
+	}
+	return resources.CPU
+}
+
+// GetMemory returns the memory limit in GB, the default limit is returned if none was requested
+func (resources Resources) GetMemory() float64 {
+	if resources.Memory == 0 {
+		return DefaultMemory
+	}
+	return resources.Memory
+}
+
 // ApplicationConfig is the configuration required for creating an application
 type ApplicationConfig struct {
 	Name          string                      `json:"name" bson:"name" valid:"required~Field 'name' is required but was not provided,alphanum~Field 'name' should only have alphanumeric characters,stringlength(3|40)~Field 'name' should have length between 3 to 40 characters,lowercase~Field 'name' should have only lowercase characters"`
@@ -76,6 +108,14 @@ type ApplicationConfig struct {
 	PublicIP      string                      `json:"public_ip,omitempty" bson:"public_ip,omitempty"`
 	SSHCmd        string                      `json:"ssh_cmd,omitempty" bson:"ssh_cmd,omitempty"`
 	Owner         string                      `json:"owner,omitempty" bson:"owner,omitempty"`
+	State         string                      `json:"state,omitempty" bson:"state,omitempty"`
+	RebuildState  string                      `json:"rebuild_state,omitempty" bson:"rebuild_state,omitempty"`
+	Commit        string                      `json:"commit,omitempty" bson:"commit,omitempty"`
+	Replicas      int                         `json:"replicas,omitempty" bson:"replicas,omitempty"`
+	Placement     Placement                   `json:"placement,omitempty" bson:"placement,omitempty"`
+	IdleTimeout   int                         `json:"idle_timeout,omitempty" bson:"idle_timeout,omitempty"`
+	HTTPS         *HTTPSPolicy                `json:"https,omitempty" bson:"https,omitempty"`
+	Access        *AccessPolicy               `json:"access,omitempty" bson:"access,omitempty"`
 	Success       bool                        `json:"success,omitempty" bson:"-"`
 }
 
@@ -98,6 +138,29 @@ func (app *ApplicationConfig) GetGitRepositoryBranch() string {
 	return app.Git.Branch
 }
 
+// GetGitReference returns the git reference to clone from the application's git repository
+// which is the tag the application is pinned to or else its branch
+func (app *ApplicationConfig) GetGitReference() string {
+	if app.Git.Ref == "" {
+		return fmt.Sprintf("refs/heads/%s", app.GetGitRepositoryBranch())
+	}
+	if strings.HasPrefix(app.Git.Ref, "refs/") {
+		return app.Git.Ref
+	}
+	return fmt.Sprintf("refs/tags/%s", app.Git.Ref)
+}
+
+// GetGitCommit returns the hash of the commit the application is pinned to
+func (app *ApplicationConfig) GetGitCommit() string {
+	return app.Git.Commit
+}
+
+// IsGitPinned checks whether the application is pinned to a tag or a commit
+// instead of following the latest commit of its branch
+func (app *ApplicationConfig) IsGitPinned() bool {
+	return app.Git.Ref != "" || app.Git.Commit != ""
+}
+
 // HasGitAccessToken checks whether access token is required for cloning
 // the application's git repository
 func (app *ApplicationConfig) HasGitAccessToken() bool {
@@ -189,6 +252,16 @@ func (app *ApplicationConfig) GetDockerImage() string {
 	return app.DockerImage
 }
 
+// GetDockerfile returns the path of the Dockerfile relative to the root of the
+// application's git repository
+// Default path is `Dockerfile`
+func (app *ApplicationConfig) GetDockerfile() string {
+	if app.Context.Dockerfile == "" {
+		return "Dockerfile"
+	}
+	return app.Context.Dockerfile
+}
+
 // SetContainerID sets docker container ID in the application's context
 func (app *ApplicationConfig) SetContainerID(id string) {
 	app.ContainerID = id
@@ -279,3 +352,49 @@ func (app *ApplicationConfig) SetSSHCmd(port int, appName, IP string) {
 func (app *ApplicationConfig) SetOwner(owner string) {
 	app.Owner = owner
 }
+
+// SetState sets the lifecycle state of the application in its context
+func (app *ApplicationConfig) SetState(state string) {
+	app.State = state
+}
+
+// GetState returns the lifecycle state of the application
+func (app *ApplicationConfig) GetState() string {
+	return app.State
+}
+
+// SetCommit sets the hash of the git commit deployed for the application
+func (app *ApplicationConfig) SetCommit(commit string) {
+	app.Commit = commit
+}
+
+// GetCommit returns the hash of the git commit deployed for the application
+func (app *ApplicationConfig) GetCommit() string {
+	return app.Commit
+}
+
+// SetReplicas sets the number of replicas the application is scaled to
+func (app *ApplicationConfig) SetReplicas(replicas int) {
+	app.Replicas = replicas
+}
+
+// GetReplicas returns the number of replicas the application is scaled to
+// Every application has at least one replica which is the application itself
+func (app *ApplicationConfig) GetReplicas() int {
+	if app.Replicas < 1 {
+		return 1
+	}
+	return app.Replicas
+}
+
+// GetIdleTimeout returns the duration without requests after which the application is put to sleep
+// Zero is returned if the application never sleeps
+func (app *ApplicationConfig) GetIdleTimeout() time.Duration {
+	if app.IdleTimeout <= 0 {
+		return 0
+	}
+	if app.IdleTimeout < MinIdleTimeout {
+		return MinIdleTimeout * time.Minute
+	}
+	return time.Duration(app.IdleTimeout) * time.Minute
+}
@@ -0,0 +1,28 @@
+package types
+
+// BuildStep stores the outcome of a single command executed while building an application
+type BuildStep struct {
+	Command  string   `json:"command" bson:"command"`
+	ExitCode int      `json:"exit_code" bson:"exit_code"`
+	Output   []string `json:"output,omitempty" bson:"output,omitempty"`
+}
+
+// Build stores the record of a single build of an application
+type Build struct {
+	ID         string      `json:"id" bson:"id"`
+	Name       string      `json:"name" bson:"name"`
+	Status     string      `json:"status" bson:"status"`
+	Steps      []BuildStep `json:"steps,omitempty" bson:"steps,omitempty"`
+	Error      string      `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  int64       `json:"started_at" bson:"started_at"`
+	FinishedAt int64       `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+// AddStep appends the outcome of a build command to the build record
+func (build *Build) AddStep(command string, exitCode int, output []string) {
+	build.Steps = append(build.Steps, BuildStep{
+		Command:  command,
+		ExitCode: exitCode,
+		Output:   output,
+	})
+}
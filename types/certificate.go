@@ -0,0 +1,14 @@
+package types
+
+// Certificate stores a TLS certificate obtained over ACME for a custom domain along with its renewal state
+// The private key is stored encrypted and neither the certificate nor the key are sent in API responses
+type Certificate struct {
+	Hostname      string `json:"hostname" bson:"hostname"`
+	Certificate   string `json:"-" bson:"certificate,omitempty"`
+	PrivateKey    string `json:"-" bson:"private_key,omitempty"`
+	NotAfter      int64  `json:"not_after,omitempty" bson:"not_after,omitempty"`
+	IssuedAt      int64  `json:"issued_at,omitempty" bson:"issued_at,omitempty"`
+	Attempts      int    `json:"attempts" bson:"attempts"`
+	LastError     string `json:"last_error,omitempty" bson:"last_error"`
+	NextAttemptAt int64  `json:"next_attempt_at,omitempty" bson:"next_attempt_at"`
+}
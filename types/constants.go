@@ -43,9 +43,86 @@ const (
 	// Jikan holds the name of `jikan` microservice
 	Jikan = "jikan"
 
+	// Docker holds the name of `docker` language under `appmaker` which builds
+	// applications from the Dockerfile present in their git repository
+	Docker = "docker"
+
+	// AppCloning is the state of an application whose repository is being cloned
+	AppCloning = "cloning"
+
+	// AppBuilding is the state of an application whose build commands are being executed
+	AppBuilding = "building"
+
+	// AppStarting is the state of an application whose run commands are being executed
+	AppStarting = "starting"
+
+	// AppRunning is the state of an application which has been deployed successfully
+	AppRunning = "running"
+
+	// AppFailed is the state of an application whose deployment has failed
+	AppFailed = "failed"
+
+	// AppStopped is the state of an application which has been stopped by its owner
+	AppStopped = "stopped"
+
+	// AppSleeping is the state of an application which has been stopped for receiving no requests
+	// and is started again by the next request to it
+	AppSleeping = "sleeping"
+
+	// BuildInProgress is the status of a build which has not finished yet
+	BuildInProgress = "in_progress"
+
+	// BuildSucceeded is the status of a build whose commands exited successfully
+	BuildSucceeded = "succeeded"
+
+	// BuildFailed is the status of a build in which a command failed
+	BuildFailed = "failed"
+
+	// DeliveryIgnored is the status of a webhook delivery which didn't require a rebuild
+	DeliveryIgnored = "ignored"
+
+	// DeliveryTriggered is the status of a webhook delivery whose rebuild is in progress
+	DeliveryTriggered = "triggered"
+
+	// DeliverySucceeded is the status of a webhook delivery whose rebuild succeeded
+	DeliverySucceeded = "succeeded"
+
+	// DeliveryFailed is the status of a webhook delivery whose rebuild failed
+	DeliveryFailed = "failed"
+
+	// NodeDraining is the state of a drain whose instances are being moved off the node
+	NodeDraining = "draining"
+
+	// NodeDrained is the state of a drain which has finished moving instances off the node
+	NodeDrained = "drained"
+
+	// DrainedApplication is the kind of an application moved off a node while draining it
+	DrainedApplication = "application"
+
+	// DrainedReplica is the kind of a replica of an application moved off a node while draining it
+	DrainedReplica = "replica"
+
+	// DrainedDatabase is the kind of a database left on a node while draining it
+	DrainedDatabase = "database"
+
+	// RescheduleQueued is the state of a rescheduling job waiting for its next attempt
+	RescheduleQueued = "queued"
+
+	// RescheduleRunning is the state of a rescheduling job being attempted
+	RescheduleRunning = "running"
+
+	// RescheduleStuck is the state of a rescheduling job which ran out of attempts
+	RescheduleStuck = "stuck"
+
 	// DefaultMemory is the default memory allotted to a container
 	DefaultMemory = 0.5
 
 	// DefaultCPUs is the default number of CPUs allotted to a container
 	DefaultCPUs = 0.25
+
+	// MaxReplicas is the maximum number of replicas an application can be scaled to
+	MaxReplicas = 10
+
+	// MinIdleTimeout is the minimum time (in minutes) without requests after which an application is put to sleep
+	MinIdleTimeout = 5
 )
@@ -24,6 +24,11 @@ type ApplicationContainer struct {
 	NameServers []string
 }
 
+// HasStoreDir checks whether an application container needs a host directory to be mounted
+func (containerCfg *ApplicationContainer) HasStoreDir() bool {
+	return containerCfg.StoreDir != ""
+}
+
 // DatabaseContainer is the configuration for creating a container
 // for running an database service
 type DatabaseContainer struct {
@@ -0,0 +1,12 @@
+package types
+
+// DeployKey stores the SSH key pair used for accessing the private git repository of an application
+// The private key is stored encrypted and is never sent in API responses
+type DeployKey struct {
+	Name       string `json:"name" bson:"name"`
+	Owner      string `json:"owner" bson:"owner"`
+	PublicKey  string `json:"public_key" bson:"public_key"`
+	PrivateKey string `json:"-" bson:"private_key"`
+	Generated  bool   `json:"generated" bson:"generated"`
+	CreatedAt  int64  `json:"created_at" bson:"created_at"`
+}
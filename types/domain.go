@@ -0,0 +1,47 @@
+package types
+
+import "fmt"
+
+const (
+	// DNSChallenge verifies the ownership of a domain through a TXT record
+	DNSChallenge = "dns"
+
+	// HTTPChallenge verifies the ownership of a domain through a request answered by GenProxy
+	HTTPChallenge = "http"
+
+	// DomainChallengeRecord is the label prepended to a domain for the TXT record holding its challenge token
+	DomainChallengeRecord = "_gasper-challenge"
+
+	// DomainChallengePath is the path under which GenProxy answers the HTTP challenges of domains
+	DomainChallengePath = "/.well-known/gasper-challenge/"
+)
+
+// Domain stores a custom hostname attached to an application
+// The application is served on the hostname only after its ownership has been verified
+type Domain struct {
+	Hostname   string `json:"hostname" bson:"hostname"`
+	Name       string `json:"name" bson:"name"`
+	Method     string `json:"method" bson:"method"`
+	Token      string `json:"token" bson:"token"`
+	Verified   bool   `json:"verified" bson:"verified"`
+	CreatedAt  int64  `json:"created_at" bson:"created_at"`
+	VerifiedAt int64  `json:"verified_at,omitempty" bson:"verified_at,omitempty"`
+}
+
+// ChallengeRecord returns the name of the TXT record which must hold the challenge token of the domain
+func (domain *Domain) ChallengeRecord() string {
+	return fmt.Sprintf("%s.%s", DomainChallengeRecord, domain.Hostname)
+}
+
+// ChallengeURL returns the URL at which the challenge token of the domain must be served
+func (domain *Domain) ChallengeURL() string {
+	return fmt.Sprintf("http://%s%s%s", domain.Hostname, DomainChallengePath, domain.Token)
+}
+
+// Instructions returns the steps for verifying the ownership of the domain
+func (domain *Domain) Instructions() string {
+	if domain.Method == DNSChallenge {
+		return fmt.Sprintf("Create a TXT record %s with the value %s", domain.ChallengeRecord(), domain.Token)
+	}
+	return fmt.Sprintf("Point %s to GenProxy so that %s responds with %s", domain.Hostname, domain.ChallengeURL(), domain.Token)
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// HTTPAllowed is the HTTPS policy which serves an application over both HTTP and HTTPS
+	HTTPAllowed = "allow"
+
+	// HTTPRedirect is the HTTPS policy which redirects the HTTP requests to an application to HTTPS
+	HTTPRedirect = "redirect"
+
+	// HTTPSOnly is the HTTPS policy which rejects the HTTP requests to an application
+	HTTPSOnly = "https_only"
+
+	// hstsPreloadMinAge is the minimum max-age (in seconds) accepted by the HSTS preload list
+	hstsPreloadMinAge = 31536000
+)
+
+// HSTS defines the Strict-Transport-Security header sent with the HTTPS responses of an application
+type HSTS struct {
+	MaxAge            int64 `json:"max_age" bson:"max_age" toml:"max_age"`
+	IncludeSubdomains bool  `json:"include_subdomains,omitempty" bson:"include_subdomains,omitempty" toml:"include_subdomains"`
+	Preload           bool  `json:"preload,omitempty" bson:"preload,omitempty" toml:"preload"`
+}
+
+// Header returns the value of the Strict-Transport-Security header, an empty string is returned if HSTS is disabled
+func (hsts *HSTS) Header() string {
+	if hsts == nil || hsts.MaxAge <= 0 {
+		return ""
+	}
+	directives := []string{fmt.Sprintf("max-age=%d", hsts.MaxAge)}
+	if hsts.IncludeSubdomains {
+		directives = append(directives, "includeSubDomains")
+	}
+	if hsts.Preload {
+		directives = append(directives, "preload")
+	}
+	return strings.Join(directives, "; ")
+}
+
+// HTTPSPolicy defines how the HTTP requests to an application are treated when GenProxy serves HTTPS
+type HTTPSPolicy struct {
+	Policy string `json:"policy" bson:"policy"`
+	HSTS   *HSTS  `json:"hsts,omitempty" bson:"hsts,omitempty"`
+}
+
+// Validate checks whether the HTTPS policy is well formed
+func (policy *HTTPSPolicy) Validate() error {
+	if policy.Policy != HTTPAllowed && policy.Policy != HTTPRedirect && policy.Policy != HTTPSOnly {
+		return fmt.Errorf("Field 'policy' should be one of `%s`, `%s` or `%s`", HTTPAllowed, HTTPRedirect, HTTPSOnly)
+	}
+	if policy.HSTS == nil {
+		return nil
+	}
+	if policy.HSTS.MaxAge < 0 {
+		return fmt.Errorf("Field 'max_age' inside field 'hsts' cannot be negative")
+	}
+	if policy.HSTS.Preload && (policy.HSTS.MaxAge < hstsPreloadMinAge || !policy.HSTS.IncludeSubdomains) {
+		return fmt.Errorf("Preloading HSTS requires 'include_subdomains' and a 'max_age' of at least %d seconds", hstsPreloadMinAge)
+	}
+	return nil
+}
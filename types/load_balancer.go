@@ -12,35 +12,80 @@ type LoadBalancer struct {
 	Instances []*ProxyInfo
 	// Counter stores the index of the server instance for directing the next request to
 	Counter int
+	// Detection defines when the instances are ejected from load balancing
+	Detection *OutlierDetection
 }
 
 // Get returns an instance from the LoadBalancer
 func (lb *LoadBalancer) Get() (*ProxyInfo, bool) {
+	return lb.GetExcluding(nil)
+}
+
+// GetExcluding returns an instance from the LoadBalancer other than the excluded ones
+// Ejected instances are skipped unless every instance has been ejected, in which case
+// requests are balanced among all of them so that the service stays reachable
+func (lb *LoadBalancer) GetExcluding(excluded map[*ProxyInfo]bool) (*ProxyInfo, bool) {
+	lb.Lock()
+	defer lb.Unlock()
 	instances := lb.Instances
 	numInstances := len(instances)
 	if numInstances == 0 {
 		return nil, false
 	}
-	instance := instances[lb.Counter%numInstances]
+	var fallback *ProxyInfo
+	for i := 0; i < numInstances; i++ {
+		instance := instances[(lb.Counter+i)%numInstances]
+		if excluded[instance] {
+			continue
+		}
+		if instance.Available() {
+			lb.Counter = (lb.Counter + i + 1) % numInstances
+			return instance, true
+		}
+		if fallback == nil {
+			fallback = instance
+		}
+	}
+	if fallback == nil {
+		return nil, false
+	}
 	lb.Counter = (lb.Counter + 1) % numInstances
-	return instance, true
+	return fallback, true
 }
 
 // Update updates the LoadBalancer instances
+// Reverse-proxy containers of the instances which are still present are retained
 func (lb *LoadBalancer) Update(newInstances []string) {
 	lb.Lock()
 	defer lb.Unlock()
+	currentInstances := make(map[string]*ProxyInfo)
+	for _, instance := range lb.Instances {
+		currentInstances[instance.host] = instance
+	}
 	newProxyInstances := make([]*ProxyInfo, 0)
 	for _, instance := range newInstances {
-		newProxyInstances = append(newProxyInstances, NewProxyInfo(instance))
+		if proxy, ok := currentInstances[instance]; ok {
+			newProxyInstances = append(newProxyInstances, proxy)
+			continue
+		}
+		newProxyInstances = append(newProxyInstances, NewProxyInfo(instance, lb.Detection))
 	}
 	lb.Instances = newProxyInstances
 }
 
+// List returns the instances of the LoadBalancer
+func (lb *LoadBalancer) List() []*ProxyInfo {
+	lb.Lock()
+	defer lb.Unlock()
+	return append([]*ProxyInfo{}, lb.Instances...)
+}
+
 // NewLoadBalancer returns a new LoadBalancer instance
-func NewLoadBalancer() *LoadBalancer {
+// The instances are never ejected if no outlier detection is given
+func NewLoadBalancer(detection *OutlierDetection) *LoadBalancer {
 	return &LoadBalancer{
 		Instances: make([]*ProxyInfo, 0),
 		Counter:   0,
+		Detection: detection,
 	}
 }
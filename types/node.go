@@ -0,0 +1,118 @@
+package types
+
+// NodeCapacity holds the resources of a worker node along with the resources which are
+// not requested by any application or replica deployed on it
+// CPU is in units of CPUs and Memory is in GB, same as the resources requested by applications
+type NodeCapacity struct {
+	CPU               float64 `json:"cpu"`
+	Memory            float64 `json:"memory"`
+	AllocatableCPU    float64 `json:"allocatable_cpu"`
+	AllocatableMemory float64 `json:"allocatable_memory"`
+}
+
+// Fits returns whether the requested resources can be allocated on the node
+func (capacity *NodeCapacity) Fits(resources Resources) bool {
+	return resources.GetCPU() <= capacity.AllocatableCPU && resources.GetMemory() <= capacity.AllocatableMemory
+}
+
+// Allocate reserves the requested resources on the node
+func (capacity *NodeCapacity) Allocate(resources Resources) {
+	capacity.AllocatableCPU -= resources.GetCPU()
+	capacity.AllocatableMemory -= resources.GetMemory()
+}
+
+// CapacityReservation holds the resources reserved on a worker node for an instance which has been
+// placed on the node but is not stored yet, the reservation is dropped once it expires
+type CapacityReservation struct {
+	CPU       float64 `json:"cpu"`
+	Memory    float64 `json:"memory"`
+	ExpiresAt int64   `json:"expires_at"`
+}
+
+// Availability returns the fraction of the node's resources which can still be allocated
+// The scarcer of CPU and memory determines the availability
+func (capacity *NodeCapacity) Availability() float64 {
+	if capacity.CPU <= 0 || capacity.Memory <= 0 {
+		return 0
+	}
+	cpu := capacity.AllocatableCPU / capacity.CPU
+	memory := capacity.AllocatableMemory / capacity.Memory
+	if cpu < memory {
+		return cpu
+	}
+	return memory
+}
+
+// NodeLabels holds the labels attached to a node by an admin
+// Nodes are identified by their IP address
+type NodeLabels struct {
+	HostIP string            `json:"host_ip" bson:"host_ip"`
+	Labels map[string]string `json:"labels" bson:"labels"`
+}
+
+// Drain holds the progress of moving all applications and replicas off a node
+// Instances which are not moved by a drain, such as databases, are listed as skipped
+// and keep running on the node
+type Drain struct {
+	HostIP      string         `json:"host_ip"`
+	State       string         `json:"state"`
+	Concurrency int            `json:"concurrency"`
+	Total       int            `json:"total"`
+	Moved       int            `json:"moved"`
+	Failed      []DrainFailure `json:"failed"`
+	Skipped     []DrainSkip    `json:"skipped"`
+	StartedAt   int64          `json:"started_at"`
+	FinishedAt  int64          `json:"finished_at,omitempty"`
+}
+
+// DrainFailure holds an instance which could not be moved off a node while draining it
+type DrainFailure struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// DrainSkip holds an instance which is left on a node while draining it along with the reason
+type DrainSkip struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// IsRunning returns whether instances are still being moved off the node
+func (drain *Drain) IsRunning() bool {
+	return drain.State == NodeDraining
+}
+
+// Heartbeat holds the health of the microservices running on a node
+// Nodes send heartbeats periodically and a node is considered dead once its heartbeat expires
+type Heartbeat struct {
+	HostIP    string                   `json:"host_ip"`
+	LastSeen  int64                    `json:"last_seen"`
+	ExpiresAt int64                    `json:"expires_at"`
+	Alive     bool                     `json:"alive"`
+	Services  map[string]ServiceHealth `json:"services"`
+}
+
+// maxUnhealthyBeats is the number of consecutive heartbeats in which a microservice can be reported
+// as unhealthy before it is considered dead, so that a service which is briefly unhealthy isn't removed
+const maxUnhealthyBeats = 3
+
+// ServiceHealth holds the health of a microservice as reported by its node
+// UnhealthyBeats is the number of consecutive heartbeats in which the microservice has been unhealthy
+type ServiceHealth struct {
+	Instance       string `json:"instance"`
+	Healthy        bool   `json:"healthy"`
+	UnhealthyBeats int    `json:"unhealthy_beats,omitempty"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+// Reports returns whether the node reported the instance of a microservice in its heartbeat as alive,
+// an instance is no longer alive once it has been unhealthy for maxUnhealthyBeats consecutive heartbeats
+func (heartbeat *Heartbeat) Reports(service, instance string) bool {
+	health, ok := heartbeat.Services[service]
+	if !ok || health.Instance != instance {
+		return false
+	}
+	return health.Healthy || health.UnhealthyBeats < maxUnhealthyBeats
+}
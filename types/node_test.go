@@ -0,0 +1,60 @@
+package types
+
+import "testing"
+
+func TestHeartbeatReports(t *testing.T) {
+	const instance = "10.0.0.12:3001"
+	tests := []struct {
+		name     string
+		health   ServiceHealth
+		service  string
+		instance string
+		want     bool
+	}{
+		{
+			name:     "healthy",
+			health:   ServiceHealth{Instance: instance, Healthy: true},
+			service:  AppMaker,
+			instance: instance,
+			want:     true,
+		},
+		{
+			name:     "briefly unhealthy",
+			health:   ServiceHealth{Instance: instance, UnhealthyBeats: 1},
+			service:  AppMaker,
+			instance: instance,
+			want:     true,
+		},
+		{
+			name:     "unhealthy",
+			health:   ServiceHealth{Instance: instance, UnhealthyBeats: maxUnhealthyBeats},
+			service:  AppMaker,
+			instance: instance,
+			want:     false,
+		},
+		{
+			name:     "other instance",
+			health:   ServiceHealth{Instance: "10.0.0.13:3001", Healthy: true},
+			service:  AppMaker,
+			instance: instance,
+			want:     false,
+		},
+		{
+			name:     "other service",
+			health:   ServiceHealth{Instance: instance, Healthy: true},
+			service:  MySQL,
+			instance: instance,
+			want:     false,
+		},
+	}
+	for _, test := range tests {
+		heartbeat := &Heartbeat{
+			HostIP:   "10.0.0.12",
+			Alive:    true,
+			Services: map[string]ServiceHealth{AppMaker: test.health},
+		}
+		if got := heartbeat.Reports(test.service, test.instance); got != test.want {
+			t.Errorf("%s: Reports(%q, %q) = %v, want %v", test.name, test.service, test.instance, got, test.want)
+		}
+	}
+}
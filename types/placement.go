@@ -0,0 +1,21 @@
+package types
+
+// Placement holds the constraints on the nodes on which an instance can be placed
+type Placement struct {
+	// NodeSelector holds the labels which a node must have for the instance to be placed on it
+	NodeSelector map[string]string `json:"node_selector,omitempty" bson:"node_selector,omitempty"`
+
+	// AntiAffinity holds the label keys whose values must be different for the nodes
+	// on which the replicas of an application are placed
+	AntiAffinity []string `json:"anti_affinity,omitempty" bson:"anti_affinity,omitempty"`
+}
+
+// Matches returns whether a node having the given labels satisfies the node selector
+func (placement *Placement) Matches(labels map[string]string) bool {
+	for key, value := range placement.NodeSelector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -1,21 +1,96 @@
 package types
 
 import (
+	"context"
 	"net/http"
 	"net/http/httputil"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// proxyAttemptKey is the key of the request context holding the attempt to proxy the request
+type proxyAttemptKey struct{}
+
+// proxyAttempt holds the error which occurred while proxying a request to an upstream
+type proxyAttempt struct {
+	err error
+}
+
+// OutlierDetection defines when an upstream is ejected from load balancing
+// An upstream is ejected after MaxFailures consecutive 5xx responses or connection failures and
+// is sent requests again only after EjectionTime has passed and it has passed a health check
+type OutlierDetection struct {
+	MaxFailures  int
+	EjectionTime time.Duration
+}
+
 // ProxyInfo is a container for establishing a reverse-proxy connection
 type ProxyInfo struct {
 	host       string
 	connection *httputil.ReverseProxy
+	detection  *OutlierDetection
+
+	mutex        sync.Mutex
+	failures     int
+	ejected      bool
+	ejectedUntil time.Time
 }
 
 // Serve establishes a reverse proxy connection
-func (proxy *ProxyInfo) Serve(c *gin.Context) {
-	proxy.connection.ServeHTTP(c.Writer, c.Request)
+// If the upstream cannot be reached nothing is written to the response and the error is returned
+// so that the request can be retried on another upstream
+func (proxy *ProxyInfo) Serve(c *gin.Context) error {
+	attempt := &proxyAttempt{}
+	req := c.Request.WithContext(context.WithValue(c.Request.Context(), proxyAttemptKey{}, attempt))
+	proxy.connection.ServeHTTP(c.Writer, req)
+	return attempt.err
+}
+
+// Host returns the address (IP:Port) to which the requests are proxied
+func (proxy *ProxyInfo) Host() string {
+	return proxy.host
+}
+
+// Available checks whether the upstream can be sent requests
+func (proxy *ProxyInfo) Available() bool {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	return !proxy.ejected
+}
+
+// report records the outcome of a request to the upstream or a health check of the upstream
+// and ejects the upstream if it has failed too many times in a row
+func (proxy *ProxyInfo) report(failed bool) {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	if !failed {
+		proxy.failures = 0
+		return
+	}
+	proxy.failures++
+	if proxy.detection == nil || proxy.ejected || proxy.failures < proxy.detection.MaxFailures {
+		return
+	}
+	proxy.ejected = true
+	proxy.ejectedUntil = time.Now().Add(proxy.detection.EjectionTime)
+	proxy.failures = 0
+}
+
+// Probe records the outcome of an active health check of the upstream
+// An ejected upstream is sent requests again once its ejection time has passed and it is healthy
+func (proxy *ProxyInfo) Probe(healthy bool) {
+	if !healthy {
+		proxy.report(true)
+		return
+	}
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	if proxy.ejected && time.Now().After(proxy.ejectedUntil) {
+		proxy.ejected = false
+		proxy.failures = 0
+	}
 }
 
 // UpdateDirector updates the endpoint in case of any change in the system
@@ -29,9 +104,11 @@ func (proxy *ProxyInfo) UpdateDirector(host string) {
 }
 
 // NewProxyInfo returns a new ProxyInfo container
-func NewProxyInfo(host string) *ProxyInfo {
-	return &ProxyInfo{
-		host: host,
+// The upstream is never ejected if no outlier detection is given
+func NewProxyInfo(host string, detection *OutlierDetection) *ProxyInfo {
+	proxy := &ProxyInfo{
+		host:      host,
+		detection: detection,
 		connection: &httputil.ReverseProxy{
 			Director: func(req *http.Request) {
 				req.URL.Scheme = "http"
@@ -40,4 +117,20 @@ func NewProxyInfo(host string) *ProxyInfo {
 			},
 		},
 	}
+	proxy.connection.ModifyResponse = func(res *http.Response) error {
+		proxy.report(res.StatusCode >= 500)
+		return nil
+	}
+	proxy.connection.ErrorHandler = func(rw http.ResponseWriter, req *http.Request, err error) {
+		// Requests cancelled by the client say nothing about the health of the upstream
+		if req.Context().Err() == nil {
+			proxy.report(true)
+		}
+		if attempt, ok := req.Context().Value(proxyAttemptKey{}).(*proxyAttempt); ok {
+			attempt.err = err
+			return
+		}
+		rw.WriteHeader(http.StatusBadGateway)
+	}
+	return proxy
 }
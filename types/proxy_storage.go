@@ -2,37 +2,89 @@ package types
 
 import "sync"
 
-// ProxyStorage maps the application name to its appropriate reverse-proxy container
+// ProxyStorage maps the application name to the load balancer of its reverse-proxy containers
+// An application has a reverse-proxy container for each of its replicas
 type ProxyStorage struct {
 	sync.Mutex
-	Holder map[string]*ProxyInfo
+	Holder map[string]*LoadBalancer
+	// Detection defines when the reverse-proxy containers are ejected from load balancing
+	Detection *OutlierDetection
 }
 
-// Get returns an application's reverse-proxy container along with a success message
+// Get returns a reverse-proxy container of an application along with a success message
+// Requests are balanced among the application's replicas using round-robin scheduling
 func (ps *ProxyStorage) Get(key string) (*ProxyInfo, bool) {
-	value, success := ps.Holder[key]
-	return value, success
+	return ps.GetExcluding(key, nil)
+}
+
+// GetExcluding returns a reverse-proxy container of an application other than the excluded ones
+// along with a success message
+func (ps *ProxyStorage) GetExcluding(key string, excluded map[*ProxyInfo]bool) (*ProxyInfo, bool) {
+	ps.Lock()
+	balancer, success := ps.Holder[key]
+	ps.Unlock()
+	if !success {
+		return nil, false
+	}
+	return balancer.GetExcluding(excluded)
 }
 
 // Update updates the application information in the ProxyStorage container
-func (ps *ProxyStorage) Update(body map[string]string) {
+// Applications missing from the body are removed from the container
+func (ps *ProxyStorage) Update(body map[string][]string) {
 	ps.Lock()
 	defer ps.Unlock()
-	for name, host := range body {
+	for name, hosts := range body {
 		if ps.Holder[name] == nil {
-			ps.Holder[name] = NewProxyInfo(host)
-			continue
+			ps.Holder[name] = NewLoadBalancer(ps.Detection)
 		}
-		if ps.Holder[name].host == host {
-			continue
+		ps.Holder[name].Update(hosts)
+	}
+	for name := range ps.Holder {
+		if _, found := body[name]; !found {
+			delete(ps.Holder, name)
 		}
-		ps.Holder[name].UpdateDirector(host)
 	}
 }
 
+// Set adds or updates the information of a single application in the ProxyStorage container
+func (ps *ProxyStorage) Set(name string, hosts []string) {
+	ps.Lock()
+	defer ps.Unlock()
+	if ps.Holder[name] == nil {
+		ps.Holder[name] = NewLoadBalancer(ps.Detection)
+	}
+	ps.Holder[name].Update(hosts)
+}
+
+// Remove removes an application from the ProxyStorage container
+func (ps *ProxyStorage) Remove(name string) {
+	ps.Lock()
+	defer ps.Unlock()
+	delete(ps.Holder, name)
+}
+
+// List returns the reverse-proxy containers of all applications
+func (ps *ProxyStorage) List() []*ProxyInfo {
+	ps.Lock()
+	balancers := make([]*LoadBalancer, 0, len(ps.Holder))
+	for _, balancer := range ps.Holder {
+		balancers = append(balancers, balancer)
+	}
+	ps.Unlock()
+
+	instances := make([]*ProxyInfo, 0)
+	for _, balancer := range balancers {
+		instances = append(instances, balancer.List()...)
+	}
+	return instances
+}
+
 // NewProxyStorage returns a new ProxyStorage container
-func NewProxyStorage() *ProxyStorage {
+// The reverse-proxy containers are never ejected if no outlier detection is given
+func NewProxyStorage(detection *OutlierDetection) *ProxyStorage {
 	return &ProxyStorage{
-		Holder: make(map[string]*ProxyInfo),
+		Holder:    make(map[string]*LoadBalancer),
+		Detection: detection,
 	}
 }
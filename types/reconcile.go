@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+// ReconcileReport holds the differences found between the instances stored for a node and the containers and
+// storage directories present on it along with the actions taken for them
+// In a dry run the actions are only reported and not taken
+type ReconcileReport struct {
+	HostIP           string            `json:"host_ip"`
+	Service          string            `json:"service"`
+	DryRun           bool              `json:"dry_run"`
+	Restarted        []string          `json:"restarted"`
+	Recreated        []string          `json:"recreated"`
+	OrphanContainers []string          `json:"orphan_containers"`
+	OrphanStorage    []string          `json:"orphan_storage"`
+	Collected        bool              `json:"collected"`
+	Errors           map[string]string `json:"errors"`
+	CreatedAt        int64             `json:"created_at"`
+}
+
+// NewReconcileReport returns an empty report for reconciling a service on a node
+func NewReconcileReport(hostIP, service string, dryRun bool) *ReconcileReport {
+	return &ReconcileReport{
+		HostIP:           hostIP,
+		Service:          service,
+		DryRun:           dryRun,
+		Restarted:        []string{},
+		Recreated:        []string{},
+		OrphanContainers: []string{},
+		OrphanStorage:    []string{},
+		Errors:           make(map[string]string),
+		CreatedAt:        time.Now().Unix(),
+	}
+}
+
+// Fail records the error encountered while reconciling an instance
+func (report *ReconcileReport) Fail(name string, err error) {
+	report.Errors[name] = err.Error()
+}
@@ -11,6 +11,8 @@ type RecordStorage struct {
 
 // Get retrieves a record from the storage
 func (rs *RecordStorage) Get(key string) (string, bool) {
+	rs.Lock()
+	defer rs.Unlock()
 	value, success := rs.Holder[key]
 	return value, success
 }
@@ -31,6 +33,13 @@ func (rs *RecordStorage) SetBulk(data map[string]string) {
 	}
 }
 
+// Delete removes a single record from the storage
+func (rs *RecordStorage) Delete(key string) {
+	rs.Lock()
+	defer rs.Unlock()
+	delete(rs.Holder, key)
+}
+
 // Replace replaces the records in the storage with new records
 func (rs *RecordStorage) Replace(replacement map[string]string) {
 	rs.Lock()
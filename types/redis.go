@@ -4,4 +4,38 @@ package types
 type InstanceBindings struct {
 	Node   string `json:"node"`
 	Server string `json:"server"`
+	// Replicas holds the bindings of the application's replicas running on other nodes
+	Replicas []InstanceBindings `json:"replicas,omitempty"`
+	// Stopped denotes that the application has been stopped by its owner and doesn't serve requests
+	Stopped bool `json:"stopped,omitempty"`
+	// Sleeping denotes that the application has been put to sleep for receiving no requests
+	Sleeping bool `json:"sleeping,omitempty"`
+}
+
+// Servers returns the server urls of the instance along with those of its replicas
+func (bindings *InstanceBindings) Servers() []string {
+	servers := []string{bindings.Server}
+	for _, replica := range bindings.Replicas {
+		servers = append(servers, replica.Server)
+	}
+	return servers
+}
+
+// Refresh updates the server and node urls of the instance along with those of its replicas from the
+// latest bindings, an application marked as stopped or sleeping stays so as it is started explicitly
+func (bindings *InstanceBindings) Refresh(latest *InstanceBindings) {
+	bindings.Node = latest.Node
+	bindings.Server = latest.Server
+	bindings.Replicas = latest.Replicas
+	bindings.Stopped = bindings.Stopped || latest.Stopped
+	bindings.Sleeping = bindings.Sleeping || latest.Sleeping
+}
+
+// InstanceEvent is published when the bindings of an instance are registered, updated or removed
+// Key is the name of the HashMap holding the bindings of the instance
+type InstanceEvent struct {
+	Key      string            `json:"key"`
+	Name     string            `json:"name"`
+	Removed  bool              `json:"removed,omitempty"`
+	Bindings *InstanceBindings `json:"bindings,omitempty"`
 }
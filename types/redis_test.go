@@ -0,0 +1,61 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstanceBindingsRefreshKeepsStopped(t *testing.T) {
+	bindings := &InstanceBindings{
+		Node:    "10.0.0.12:3001",
+		Server:  "10.0.0.12:55163",
+		Stopped: true,
+	}
+	latest := &InstanceBindings{
+		Node:   "10.0.0.12:3001",
+		Server: "10.0.0.12:55163",
+		Replicas: []InstanceBindings{
+			{Node: "10.0.0.13:3001", Server: "10.0.0.13:41532"},
+		},
+	}
+	bindings.Refresh(latest)
+
+	if !bindings.Stopped {
+		t.Errorf("stopped application is running after being refreshed")
+	}
+	if !reflect.DeepEqual(bindings.Replicas, latest.Replicas) {
+		t.Errorf("replicas = %v, want %v", bindings.Replicas, latest.Replicas)
+	}
+}
+
+func TestInstanceBindingsRefreshStopped(t *testing.T) {
+	bindings := &InstanceBindings{
+		Node:   "10.0.0.12:3001",
+		Server: "10.0.0.12:55163",
+	}
+	bindings.Refresh(&InstanceBindings{
+		Node:    "10.0.0.12:3001",
+		Server:  "10.0.0.12:55163",
+		Stopped: true,
+	})
+
+	if !bindings.Stopped {
+		t.Errorf("application stopped on its node is running after being refreshed")
+	}
+}
+
+func TestInstanceBindingsRefreshKeepsSleeping(t *testing.T) {
+	bindings := &InstanceBindings{
+		Node:     "10.0.0.12:3001",
+		Server:   "10.0.0.12:55163",
+		Sleeping: true,
+	}
+	bindings.Refresh(&InstanceBindings{
+		Node:   "10.0.0.12:3001",
+		Server: "10.0.0.12:55163",
+	})
+
+	if !bindings.Sleeping {
+		t.Errorf("sleeping application is awake after being refreshed")
+	}
+}
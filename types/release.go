@@ -0,0 +1,28 @@
+package types
+
+// Release stores the record of a successful deploy of an application
+// along with everything required for deploying it again
+type Release struct {
+	ID          string    `json:"id" bson:"id"`
+	Name        string    `json:"name" bson:"name"`
+	Commit      string    `json:"commit" bson:"commit"`
+	DockerImage string    `json:"docker_image" bson:"docker_image"`
+	Git         Git       `json:"git" bson:"git"`
+	Context     Context   `json:"context" bson:"context"`
+	Resources   Resources `json:"resources" bson:"resources"`
+	Env         M         `json:"env,omitempty" bson:"env,omitempty"`
+	TriggeredBy string    `json:"triggered_by" bson:"triggered_by"`
+	RollbackOf  string    `json:"rollback_of,omitempty" bson:"rollback_of,omitempty"`
+	Timestamp   int64     `json:"timestamp" bson:"timestamp"`
+}
+
+// ApplyTo restores the configuration of the release in the application
+// The git access token of the application is kept as it is never recorded in a release
+func (release *Release) ApplyTo(app *ApplicationConfig) {
+	accessToken := app.Git.AccessToken
+	app.Git = release.Git
+	app.Git.AccessToken = accessToken
+	app.Context = release.Context
+	app.Resources = release.Resources
+	app.Env = release.Env
+}
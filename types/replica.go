@@ -0,0 +1,22 @@
+package types
+
+import "fmt"
+
+// Replica is a copy of an application running on a worker node other than the application's own node
+// The application itself is its first replica hence only the additional replicas are stored
+type Replica struct {
+	Name          string    `json:"name" bson:"name"`
+	Node          string    `json:"node" bson:"node"`
+	HostIP        string    `json:"host_ip" bson:"host_ip"`
+	ContainerID   string    `json:"container_id" bson:"container_id"`
+	ContainerPort int       `json:"container_port" bson:"container_port"`
+	Commit        string    `json:"commit,omitempty" bson:"commit,omitempty"`
+	State         string    `json:"state,omitempty" bson:"state,omitempty"`
+	Resources     Resources `json:"resources" bson:"resources"`
+	CreatedAt     int64     `json:"created_at" bson:"created_at"`
+}
+
+// Server returns the URL on which the replica serves the application
+func (replica *Replica) Server() string {
+	return fmt.Sprintf("%s:%d", replica.HostIP, replica.ContainerPort)
+}
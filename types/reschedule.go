@@ -0,0 +1,14 @@
+package types
+
+// RescheduleJob holds an application waiting to be re-deployed on another worker node
+// after the node holding it was lost
+type RescheduleJob struct {
+	Name          string   `json:"name"`
+	HostIP        string   `json:"host_ip"`
+	State         string   `json:"state"`
+	Attempts      int      `json:"attempts"`
+	FailedNodes   []string `json:"failed_nodes"`
+	LastError     string   `json:"last_error,omitempty"`
+	EnqueuedAt    int64    `json:"enqueued_at"`
+	NextAttemptAt int64    `json:"next_attempt_at"`
+}
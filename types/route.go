@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RouteHeaders defines how the request headers forwarded to the application of a route are rewritten
+type RouteHeaders struct {
+	Set    map[string]string `json:"set,omitempty" bson:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty" bson:"remove,omitempty"`
+}
+
+// Route maps the requests on a hostname whose path starts with a prefix to an application
+// The hostname belongs to the application owning the route which can serve another application on it
+type Route struct {
+	Hostname    string        `json:"hostname" bson:"hostname"`
+	Path        string        `json:"path" bson:"path"`
+	Name        string        `json:"name" bson:"name"`
+	Target      string        `json:"target" bson:"target"`
+	StripPrefix bool          `json:"strip_prefix" bson:"strip_prefix"`
+	Headers     *RouteHeaders `json:"headers,omitempty" bson:"headers,omitempty"`
+	CreatedAt   int64         `json:"created_at" bson:"created_at"`
+}
+
+// NormalizeRoutePath returns the path prefix of a route without a trailing slash
+func NormalizeRoutePath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "/", nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("Field 'path' should start with `/`")
+	}
+	if strings.ContainsAny(path, "?#") {
+		return "", fmt.Errorf("Field 'path' cannot contain a query or a fragment")
+	}
+	if path != "/" {
+		path = strings.TrimRight(path, "/")
+	}
+	if path == "" {
+		path = "/"
+	}
+	return path, nil
+}
+
+// Matches checks whether a request path falls under the path prefix of the route
+// Prefixes are matched by whole segments hence `/api` matches `/api/users` but not `/apis`
+func (route *Route) Matches(path string) bool {
+	return route.Path == "/" || path == route.Path || strings.HasPrefix(path, route.Path+"/")
+}
+
+// Rewrite rewrites a request matching the route before it is forwarded to the route's application
+func (route *Route) Rewrite(req *http.Request) {
+	if route.StripPrefix && route.Path != "/" {
+		req.URL.Path = strings.TrimPrefix(req.URL.Path, route.Path)
+		if req.URL.Path == "" {
+			req.URL.Path = "/"
+		}
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = strings.TrimPrefix(req.URL.RawPath, route.Path)
+			if req.URL.RawPath == "" {
+				req.URL.RawPath = "/"
+			}
+		}
+		req.Header.Set("X-Forwarded-Prefix", route.Path)
+	}
+	if route.Headers == nil {
+		return
+	}
+	for _, header := range route.Headers.Remove {
+		req.Header.Del(header)
+	}
+	for header, value := range route.Headers.Set {
+		req.Header.Set(header, value)
+	}
+}
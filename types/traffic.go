@@ -0,0 +1,119 @@
+package types
+
+import "fmt"
+
+// LatencyBuckets are the upper bounds (in milliseconds) of the buckets of the latency histogram of an application's
+// traffic, the last bucket holds the requests slower than the last bound
+// Histograms are kept instead of percentiles as they can be merged across GenProxy instances and time periods
+var LatencyBuckets = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000}
+
+// AccessLog is the structured record of a request to an application emitted by GenProxy
+type AccessLog struct {
+	Time     string  `json:"time"`
+	App      string  `json:"app"`
+	Method   string  `json:"method"`
+	Host     string  `json:"host"`
+	Path     string  `json:"path"`
+	Status   int     `json:"status"`
+	Bytes    int     `json:"bytes"`
+	Latency  float64 `json:"latency_ms"`
+	ClientIP string  `json:"client_ip"`
+	Upstream string  `json:"upstream,omitempty"`
+}
+
+// Traffic holds the aggregates of the requests to an application served by a GenProxy instance within a minute
+type Traffic struct {
+	Name      string           `json:"name" bson:"name"`
+	Timestamp int64            `json:"timestamp" bson:"timestamp"`
+	HostIP    string           `json:"host_ip,omitempty" bson:"host_ip"`
+	Requests  int64            `json:"requests" bson:"requests"`
+	Bytes     int64            `json:"bytes" bson:"bytes"`
+	Status    map[string]int64 `json:"status" bson:"status"`
+	Latency   []int64          `json:"-" bson:"latency"`
+}
+
+// NewTraffic returns a new Traffic container for the requests to an application within the minute starting at timestamp
+func NewTraffic(name string, timestamp int64) *Traffic {
+	return &Traffic{
+		Name:      name,
+		Timestamp: timestamp,
+		Status:    make(map[string]int64),
+		Latency:   make([]int64, len(LatencyBuckets)+1),
+	}
+}
+
+// Observe adds a request to the aggregates
+func (traffic *Traffic) Observe(status, bytes int, latency float64) {
+	traffic.Requests++
+	if bytes > 0 {
+		traffic.Bytes += int64(bytes)
+	}
+	traffic.Status[fmt.Sprintf("%dxx", status/100)]++
+	bucket := len(LatencyBuckets)
+	for i, bound := range LatencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	traffic.Latency[bucket]++
+}
+
+// Merge adds the aggregates of another Traffic container to the aggregates
+func (traffic *Traffic) Merge(other *Traffic) {
+	traffic.Requests += other.Requests
+	traffic.Bytes += other.Bytes
+	for class, count := range other.Status {
+		traffic.Status[class] += count
+	}
+	for i := range traffic.Latency {
+		if i < len(other.Latency) {
+			traffic.Latency[i] += other.Latency[i]
+		}
+	}
+}
+
+// Percentile returns an estimate of the latency (in milliseconds) within which the given fraction of the
+// requests were served, the latency is interpolated linearly within the bucket holding the percentile
+func (traffic *Traffic) Percentile(fraction float64) float64 {
+	var total int64
+	for _, count := range traffic.Latency {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := fraction * float64(total)
+	var cumulative int64
+	for i, count := range traffic.Latency {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+		if i == len(LatencyBuckets) {
+			// The last bucket has no upper bound hence its lower bound is the best estimate
+			return LatencyBuckets[len(LatencyBuckets)-1]
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = LatencyBuckets[i-1]
+		}
+		return lower + (LatencyBuckets[i]-lower)*(rank-float64(cumulative))/float64(count)
+	}
+	return LatencyBuckets[len(LatencyBuckets)-1]
+}
+
+// Summary returns the aggregates along with the percentiles of the latency
+func (traffic *Traffic) Summary() M {
+	return M{
+		"timestamp": traffic.Timestamp,
+		"requests":  traffic.Requests,
+		"bytes":     traffic.Bytes,
+		"status":    traffic.Status,
+		"latency": M{
+			"p50": traffic.Percentile(0.5),
+			"p95": traffic.Percentile(0.95),
+			"p99": traffic.Percentile(0.99),
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package types
+
+// Webhook stores the secret used for verifying the push webhooks of an application
+type Webhook struct {
+	Name      string `json:"name" bson:"name"`
+	Secret    string `json:"secret" bson:"secret"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at"`
+}
+
+// WebhookDelivery stores the record of a single webhook request received for an application
+type WebhookDelivery struct {
+	ID        string `json:"id" bson:"id"`
+	Name      string `json:"name" bson:"name"`
+	Provider  string `json:"provider,omitempty" bson:"provider,omitempty"`
+	Event     string `json:"event,omitempty" bson:"event,omitempty"`
+	Ref       string `json:"ref,omitempty" bson:"ref,omitempty"`
+	Commit    string `json:"commit,omitempty" bson:"commit,omitempty"`
+	Status    string `json:"status" bson:"status"`
+	Message   string `json:"message,omitempty" bson:"message,omitempty"`
+	Timestamp int64  `json:"timestamp" bson:"timestamp"`
+}